@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.temporal.io/api/activity/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/temporalproto"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ========================================
+// Activity Operations
+// ========================================
+
+const ACTIVITY_OPERATIONS_IDENTITY = "kairos-cli"
+
+// Returned by confirmation flow commands that failed, the message replaces the success message
+type confirmationActionFailedMsg struct {
+	message string
+}
+
+type activityOptionsEditedMsg struct {
+	workflowId      string
+	runId           string
+	activityId      string
+	originalOptions *activity.ActivityOptions
+	path            string
+	err             error
+}
+
+// Activity operations refresh the pending activities of the workflow once they completed, the list is left as is
+func (m model) activityConfirmationFlowCmd(workflowId string, runId string, areYouSureMessage string, executionSuccessMessage string, operation func(context.Context, workflowservice.WorkflowServiceClient) error) tea.Cmd {
+	commandThatRunsOnConfirmation := func() tea.Msg {
+		temporalClient, _ := m.getTemporalClient()
+		if err := operation(context.Background(), temporalClient.WorkflowService()); err != nil {
+			return confirmationActionFailedMsg{message: fmt.Sprintf("Failed: %v", err)}
+		}
+		return nil
+	}
+	return func() tea.Msg {
+		return confirmationFlowStateMsg{
+			state:                         AWAITING_CONFIRMATION,
+			executionSuccessMessage:       executionSuccessMessage,
+			areYouSureMessage:             areYouSureMessage,
+			pendingConfirmationMessage:    "Running activity operation",
+			commandThatRunsOnConfirmation: commandThatRunsOnConfirmation,
+			onCompletedCmd:                m.refreshPendingActivitiesCmd(workflowId, runId),
+		}
+	}
+}
+
+// Pauses the activity, or unpauses it when it is already paused
+func (m model) togglePauseActivityCmd(workflowId string, runId string, pendingActivity *workflow.PendingActivityInfo) tea.Cmd {
+	namespace := m.getTemporalConfig().TemporalNamespace
+	activityId := pendingActivity.GetActivityId()
+	activityName := pendingActivity.GetActivityType().GetName()
+	if pendingActivity.GetPaused() {
+		return m.activityConfirmationFlowCmd(
+			workflowId,
+			runId,
+			fmt.Sprintf("Are you sure you want to unpause activity %s (%s)?", activityName, activityId),
+			"Activity unpaused",
+			func(ctx context.Context, workflowService workflowservice.WorkflowServiceClient) error {
+				_, err := workflowService.UnpauseActivityById(ctx, &workflowservice.UnpauseActivityByIdRequest{
+					Namespace:  namespace,
+					WorkflowId: workflowId,
+					RunId:      runId,
+					ActivityId: activityId,
+					Identity:   ACTIVITY_OPERATIONS_IDENTITY,
+					Operation:  &workflowservice.UnpauseActivityByIdRequest_Resume{Resume: &workflowservice.UnpauseActivityByIdRequest_ResumeOperation{}},
+				})
+				return err
+			},
+		)
+	}
+	return m.activityConfirmationFlowCmd(
+		workflowId,
+		runId,
+		fmt.Sprintf("Are you sure you want to pause activity %s (%s)?", activityName, activityId),
+		"Activity paused",
+		func(ctx context.Context, workflowService workflowservice.WorkflowServiceClient) error {
+			_, err := workflowService.PauseActivityById(ctx, &workflowservice.PauseActivityByIdRequest{
+				Namespace:  namespace,
+				WorkflowId: workflowId,
+				RunId:      runId,
+				ActivityId: activityId,
+				Identity:   ACTIVITY_OPERATIONS_IDENTITY,
+			})
+			return err
+		},
+	)
+}
+
+// Resets the attempts of the activity back to 1
+func (m model) resetActivityCmd(workflowId string, runId string, pendingActivity *workflow.PendingActivityInfo) tea.Cmd {
+	namespace := m.getTemporalConfig().TemporalNamespace
+	activityId := pendingActivity.GetActivityId()
+	return m.activityConfirmationFlowCmd(
+		workflowId,
+		runId,
+		fmt.Sprintf("Are you sure you want to reset the attempts of activity %s (%s)?", pendingActivity.GetActivityType().GetName(), activityId),
+		"Activity attempts reset",
+		func(ctx context.Context, workflowService workflowservice.WorkflowServiceClient) error {
+			_, err := workflowService.ResetActivityById(ctx, &workflowservice.ResetActivityByIdRequest{
+				Namespace:  namespace,
+				WorkflowId: workflowId,
+				RunId:      runId,
+				ActivityId: activityId,
+				Identity:   ACTIVITY_OPERATIONS_IDENTITY,
+			})
+			return err
+		},
+	)
+}
+
+// The pending activity info does not contain the options, they are read from the scheduled event
+func getActivityOptions(historyList []*history.HistoryEvent, activityId string) *activity.ActivityOptions {
+	for _, historyEvent := range historyList {
+		attributes := historyEvent.GetActivityTaskScheduledEventAttributes()
+		if attributes == nil || attributes.GetActivityId() != activityId {
+			continue
+		}
+		return &activity.ActivityOptions{
+			ScheduleToCloseTimeout: attributes.GetScheduleToCloseTimeout(),
+			ScheduleToStartTimeout: attributes.GetScheduleToStartTimeout(),
+			StartToCloseTimeout:    attributes.GetStartToCloseTimeout(),
+			HeartbeatTimeout:       attributes.GetHeartbeatTimeout(),
+			RetryPolicy:            attributes.GetRetryPolicy(),
+		}
+	}
+	return nil
+}
+
+// Only the fields that were changed in the editor are sent
+func getActivityOptionsUpdateMask(original *activity.ActivityOptions, updated *activity.ActivityOptions) []string {
+	fields := []struct {
+		path             string
+		original, update proto.Message
+	}{
+		{"schedule_to_close_timeout", original.GetScheduleToCloseTimeout(), updated.GetScheduleToCloseTimeout()},
+		{"schedule_to_start_timeout", original.GetScheduleToStartTimeout(), updated.GetScheduleToStartTimeout()},
+		{"start_to_close_timeout", original.GetStartToCloseTimeout(), updated.GetStartToCloseTimeout()},
+		{"heartbeat_timeout", original.GetHeartbeatTimeout(), updated.GetHeartbeatTimeout()},
+		{"retry_policy.initial_interval", original.GetRetryPolicy().GetInitialInterval(), updated.GetRetryPolicy().GetInitialInterval()},
+		{"retry_policy.maximum_interval", original.GetRetryPolicy().GetMaximumInterval(), updated.GetRetryPolicy().GetMaximumInterval()},
+	}
+	paths := []string{}
+	for _, field := range fields {
+		if !proto.Equal(field.original, field.update) {
+			paths = append(paths, field.path)
+		}
+	}
+	if original.GetRetryPolicy().GetBackoffCoefficient() != updated.GetRetryPolicy().GetBackoffCoefficient() {
+		paths = append(paths, "retry_policy.backoff_coefficient")
+	}
+	if original.GetRetryPolicy().GetMaximumAttempts() != updated.GetRetryPolicy().GetMaximumAttempts() {
+		paths = append(paths, "retry_policy.maximum_attempts")
+	}
+	return paths
+}
+
+// Opens the retry policy and timeouts of the activity in $EDITOR, they are applied once the editor is closed
+func (m model) editActivityOptionsCmd(workflowId string, runId string, historyList []*history.HistoryEvent, pendingActivity *workflow.PendingActivityInfo) tea.Cmd {
+	if os.Getenv("EDITOR") == "" {
+		return statusMessageCmd("Set $EDITOR to edit activity options")
+	}
+	activityId := pendingActivity.GetActivityId()
+	originalOptions := getActivityOptions(historyList, activityId)
+	if originalOptions == nil {
+		return statusMessageCmd("Scheduled event of the activity not found")
+	}
+	optionsJson, err := protoToPrettyJSON(originalOptions)
+	if err != nil {
+		return statusMessageCmd(fmt.Sprintf("Failed to convert activity options to json: %v", err))
+	}
+	return editInEditorCmd(activityId+"-options", optionsJson, func(path string, err error) tea.Msg {
+		return activityOptionsEditedMsg{workflowId: workflowId, runId: runId, activityId: activityId, originalOptions: originalOptions, path: path, err: err}
+	})
+}
+
+func (m model) handleActivityOptionsEditedMsg(msg activityOptionsEditedMsg) (tea.Model, tea.Cmd) {
+	defer os.Remove(msg.path)
+	if msg.err != nil {
+		return m, statusMessageCmd(fmt.Sprintf("Editor exited with error: %v", msg.err))
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		return m, statusMessageCmd(fmt.Sprintf("Failed to read activity options: %v", err))
+	}
+	updatedOptions := &activity.ActivityOptions{}
+	if err := (temporalproto.CustomJSONUnmarshalOptions{}).Unmarshal(data, updatedOptions); err != nil {
+		return m, statusMessageCmd(fmt.Sprintf("Invalid activity options: %v", err))
+	}
+	paths := getActivityOptionsUpdateMask(msg.originalOptions, updatedOptions)
+	if len(paths) == 0 {
+		return m, statusMessageCmd("Activity options unchanged")
+	}
+	namespace := m.getTemporalConfig().TemporalNamespace
+	return m, m.activityConfirmationFlowCmd(
+		msg.workflowId,
+		msg.runId,
+		fmt.Sprintf("Are you sure you want to update %d option(s) of activity %s?", len(paths), msg.activityId),
+		"Activity options updated",
+		func(ctx context.Context, workflowService workflowservice.WorkflowServiceClient) error {
+			_, err := workflowService.UpdateActivityOptionsById(ctx, &workflowservice.UpdateActivityOptionsByIdRequest{
+				Namespace:       namespace,
+				WorkflowId:      msg.workflowId,
+				RunId:           msg.runId,
+				ActivityId:      msg.activityId,
+				Identity:        ACTIVITY_OPERATIONS_IDENTITY,
+				ActivityOptions: updatedOptions,
+				UpdateMask:      &fieldmaskpb.FieldMask{Paths: paths},
+			})
+			return err
+		},
+	)
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ========================================
+// Bulk Export
+// ========================================
+
+const BULK_EXPORT_PAGE_SIZE = 100
+const BULK_EXPORT_HISTORY_FILE = "history.json"
+const BULK_EXPORT_DESCRIBE_FILE = "describe.json"
+
+type bulkExportResult struct {
+	exported int
+	skipped  int
+	failed   int
+}
+
+// Every execution gets its own directory, e.g. order-42-<runId>/history.json
+func getBulkExportExecutionDir(outputDir string, execution *workflow.WorkflowExecutionInfo) string {
+	return filepath.Join(outputDir, sanitizeFileName(execution.GetExecution().GetWorkflowId()+"-"+execution.GetExecution().GetRunId()))
+}
+
+// Writes to a temp file first so an interrupted export never leaves a truncated file behind
+func writeFileAtomically(path string, content string) error {
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, []byte(content), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, path)
+}
+
+// The history is written last, so an execution with a history file is complete
+func isExecutionExported(executionDir string) bool {
+	_, err := os.Stat(filepath.Join(executionDir, BULK_EXPORT_HISTORY_FILE))
+	return err == nil
+}
+
+func (m model) exportExecution(ctx context.Context, executionDir string, execution *workflow.WorkflowExecutionInfo) error {
+	temporalClient, _ := m.getTemporalClient()
+	workflowId := execution.GetExecution().GetWorkflowId()
+	runId := execution.GetExecution().GetRunId()
+	description, err := temporalClient.DescribeWorkflowExecution(ctx, workflowId, runId)
+	if err != nil {
+		return fmt.Errorf("failed to describe: %w", err)
+	}
+	events, err := m.GetWorkflowHistory(ctx, workflowId, runId)
+	if err != nil {
+		return fmt.Errorf("failed to fetch history: %w", err)
+	}
+	describeJson, err := protoToPrettyJSON(description)
+	if err != nil {
+		return err
+	}
+	historyJson, err := historyToJSON(events)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(executionDir, 0o755); err != nil {
+		return err
+	}
+	if err := writeFileAtomically(filepath.Join(executionDir, BULK_EXPORT_DESCRIBE_FILE), describeJson); err != nil {
+		return err
+	}
+	return writeFileAtomically(filepath.Join(executionDir, BULK_EXPORT_HISTORY_FILE), historyJson)
+}
+
+// Pages through the executions matching the query and exports them with at most `concurrency` at a time.
+// Executions that were already exported are skipped so an interrupted export can be resumed.
+func (m model) bulkExport(ctx context.Context, query string, outputDir string, concurrency int) (bulkExportResult, error) {
+	temporalClient, _ := m.getTemporalClient()
+	result := bulkExportResult{}
+	var resultMutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+	var nextPageToken []byte
+	for {
+		page, err := temporalClient.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			PageSize:      BULK_EXPORT_PAGE_SIZE,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			waitGroup.Wait()
+			return result, fmt.Errorf("failed to list workflows: %w", err)
+		}
+		for _, execution := range page.GetExecutions() {
+			executionDir := getBulkExportExecutionDir(outputDir, execution)
+			if isExecutionExported(executionDir) {
+				resultMutex.Lock()
+				result.skipped++
+				resultMutex.Unlock()
+				continue
+			}
+			semaphore <- struct{}{}
+			waitGroup.Add(1)
+			go func(execution *workflow.WorkflowExecutionInfo) {
+				defer waitGroup.Done()
+				defer func() { <-semaphore }()
+				err := m.exportExecution(ctx, executionDir, execution)
+				resultMutex.Lock()
+				defer resultMutex.Unlock()
+				if err != nil {
+					result.failed++
+					fmt.Fprintf(os.Stderr, "Failed to export %s (%s): %v\n", execution.GetExecution().GetWorkflowId(), execution.GetExecution().GetRunId(), err)
+					return
+				}
+				result.exported++
+				fmt.Fprintf(os.Stderr, "Exported %s (%s)\n", execution.GetExecution().GetWorkflowId(), execution.GetExecution().GetRunId())
+			}(execution)
+		}
+		nextPageToken = page.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+	waitGroup.Wait()
+	if result.failed > 0 {
+		return result, fmt.Errorf("%d executions failed to export, run the same command again to retry them", result.failed)
+	}
+	return result, nil
+}
+
+// Packs the exported directory into a tar file, only complete files are added
+func writeTarArchive(sourceDir string, tarPath string) error {
+	file, err := os.Create(tarPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	tarWriter := tar.NewWriter(file)
+	err = filepath.Walk(sourceDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if filepath.Ext(path) == ".tmp" {
+			return nil
+		}
+		relativePath, err := filepath.Rel(filepath.Dir(sourceDir), path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(fileInfo, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relativePath)
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if fileInfo.IsDir() {
+			return nil
+		}
+		source, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer source.Close()
+		_, err = io.Copy(tarWriter, source)
+		return err
+	})
+	return errors.Join(err, tarWriter.Close())
+}
+
+// kairos-cli export [-o dir] [-concurrency n] [-tar] <query>
+func bulkExportCommand(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	outputDir := flags.String("o", "kairos-export", "Output directory, rerun with the same directory to resume")
+	concurrency := flags.Int("concurrency", 4, "Number of executions exported at the same time")
+	writeTar := flags.Bool("tar", false, "Also pack the output directory into <dir>.tar once the export is complete")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kairos-cli export [-o dir] [-concurrency n] [-tar] <query>")
+		fmt.Fprintln(flags.Output(), "Example: kairos-cli export -o incident \"WorkflowType='Checkout' AND ExecutionStatus='Failed'\"")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected a visibility query")
+	}
+	if *concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+	// "." and relative paths are resolved so the tar is named after the directory and written next to it
+	absoluteOutputDir, err := filepath.Abs(*outputDir)
+	if err != nil {
+		return err
+	}
+	if *writeTar && filepath.Dir(absoluteOutputDir) == absoluteOutputDir {
+		return fmt.Errorf("-tar can not be used when the output directory is the root directory")
+	}
+	if err := os.MkdirAll(*outputDir, 0o755); err != nil {
+		return err
+	}
+	m := initialModel()
+	result, err := m.bulkExport(context.Background(), flags.Arg(0), *outputDir, *concurrency)
+	fmt.Fprintf(os.Stderr, "%d exported, %d already exported, %d failed\n", result.exported, result.skipped, result.failed)
+	if err != nil {
+		return err
+	}
+	if *writeTar {
+		tarPath := absoluteOutputDir + ".tar"
+		if err := writeTarArchive(absoluteOutputDir, tarPath); err != nil {
+			return fmt.Errorf("failed to write %s: %w", tarPath, err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", tarPath)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ========================================
+// Child Workflow Tree
+// ========================================
+
+const (
+	CHILD_TREE_MAX_DEPTH = 5
+	// Maximum number of children loaded per workflow
+	CHILD_TREE_MAX_FAN_OUT = 50
+	// Maximum number of workflows loaded for the whole tree
+	CHILD_TREE_MAX_NODES = 500
+)
+
+type childWorkflowTreeNode struct {
+	workflow *workflow.WorkflowExecutionInfo
+	children []*childWorkflowTreeNode
+	// Set when some children may not have been loaded because of the limits
+	hasMoreChildren bool
+}
+
+// A node of the tree flattened into a renderable row
+type childWorkflowTreeRow struct {
+	node   *childWorkflowTreeNode
+	prefix string
+}
+
+type childWorkflowTreeMsg struct {
+	workflowId string
+	runId      string
+	tree       *childWorkflowTreeNode
+	err        error
+}
+
+var childWorkflowTreeBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+var childWorkflowTreeMutedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
+
+func getChildWorkflowsQuery(parent *workflow.WorkflowExecutionInfo) string {
+	execution := parent.GetExecution()
+	return fmt.Sprintf("ParentWorkflowId = '%s' AND ParentRunId = '%s'", escapeQueryValue(execution.GetWorkflowId()), escapeQueryValue(execution.GetRunId()))
+}
+
+func (m *model) loadChildWorkflowTreeCmd(root *workflow.WorkflowExecutionInfo) tea.Cmd {
+	return func() tea.Msg {
+		temporalClient, _ := m.getTemporalClient()
+		rootNode := &childWorkflowTreeNode{workflow: root}
+		loadedNodes := 1
+		// Breadth first so the limits cut off the deepest generations first
+		currentGeneration := []*childWorkflowTreeNode{rootNode}
+		for depth := 0; depth < CHILD_TREE_MAX_DEPTH && len(currentGeneration) > 0; depth++ {
+			nextGeneration := []*childWorkflowTreeNode{}
+			for _, node := range currentGeneration {
+				if loadedNodes >= CHILD_TREE_MAX_NODES {
+					node.hasMoreChildren = true
+					continue
+				}
+				result, err := temporalClient.ListWorkflow(context.Background(), &workflowservice.ListWorkflowExecutionsRequest{
+					Query:    getChildWorkflowsQuery(node.workflow),
+					PageSize: int32(min(CHILD_TREE_MAX_FAN_OUT, CHILD_TREE_MAX_NODES-loadedNodes)),
+				})
+				if err != nil {
+					return childWorkflowTreeMsg{workflowId: root.GetExecution().GetWorkflowId(), runId: root.GetExecution().GetRunId(), err: err}
+				}
+				node.hasMoreChildren = len(result.GetNextPageToken()) > 0
+				for _, child := range result.GetExecutions() {
+					childNode := &childWorkflowTreeNode{workflow: child}
+					node.children = append(node.children, childNode)
+					nextGeneration = append(nextGeneration, childNode)
+					loadedNodes++
+				}
+			}
+			currentGeneration = nextGeneration
+		}
+		// The children of workflows at the maximum depth are not loaded, only checked for
+		for _, node := range currentGeneration {
+			result, err := temporalClient.ListWorkflow(context.Background(), &workflowservice.ListWorkflowExecutionsRequest{
+				Query:    getChildWorkflowsQuery(node.workflow),
+				PageSize: 1,
+			})
+			if err != nil {
+				return childWorkflowTreeMsg{workflowId: root.GetExecution().GetWorkflowId(), runId: root.GetExecution().GetRunId(), err: err}
+			}
+			node.hasMoreChildren = len(result.GetExecutions()) > 0
+		}
+		return childWorkflowTreeMsg{workflowId: root.GetExecution().GetWorkflowId(), runId: root.GetExecution().GetRunId(), tree: rootNode}
+	}
+}
+
+func flattenChildWorkflowTree(node *childWorkflowTreeNode, prefix string, childPrefix string) []childWorkflowTreeRow {
+	rows := []childWorkflowTreeRow{{node: node, prefix: prefix}}
+	for i, child := range node.children {
+		if i == len(node.children)-1 {
+			rows = append(rows, flattenChildWorkflowTree(child, childPrefix+"└─ ", childPrefix+"   ")...)
+		} else {
+			rows = append(rows, flattenChildWorkflowTree(child, childPrefix+"├─ ", childPrefix+"│  ")...)
+		}
+	}
+	return rows
+}
+
+func (m *focusedModeState) getChildWorkflowTreeRows() []childWorkflowTreeRow {
+	tree := m.getCurrentHistoryStackItem().childWorkflowTree
+	if tree == nil {
+		return []childWorkflowTreeRow{}
+	}
+	return flattenChildWorkflowTree(tree, "", "")
+}
+
+// Handles the keys of the tree view, returns false for keys that are handled by the focused mode
+func (m *model) updateChildWorkflowTree(msg tea.KeyMsg) (bool, tea.Cmd) {
+	keys := m.focusedWorkflowState.keys
+	rows := m.focusedWorkflowState.getChildWorkflowTreeRows()
+	switch {
+	case key.Matches(msg, keys.Up):
+		if m.focusedWorkflowState.cursor > 0 {
+			m.focusedWorkflowState.cursor--
+		}
+		return true, nil
+	case key.Matches(msg, keys.Down):
+		if m.focusedWorkflowState.cursor < len(rows)-1 {
+			m.focusedWorkflowState.cursor++
+		}
+		return true, nil
+	case key.Matches(msg, keys.FocusChildWorkflow):
+		// The first row is the focused workflow itself
+		if m.focusedWorkflowState.cursor > 0 && m.focusedWorkflowState.cursor < len(rows) {
+			execution := rows[m.focusedWorkflowState.cursor].node.workflow.GetExecution()
+			return true, m.setFocusedWorkflowCmd(execution.GetWorkflowId(), execution.GetRunId())
+		}
+		return true, nil
+	case key.Matches(msg, keys.YankWorkflowId):
+		if m.focusedWorkflowState.cursor < len(rows) {
+			return true, yankCmd("workflow id", rows[m.focusedWorkflowState.cursor].node.workflow.GetExecution().GetWorkflowId())
+		}
+		return true, nil
+	case key.Matches(msg, keys.YankRunId):
+		if m.focusedWorkflowState.cursor < len(rows) {
+			return true, yankCmd("run id", rows[m.focusedWorkflowState.cursor].node.workflow.GetExecution().GetRunId())
+		}
+		return true, nil
+	case keys.isHistoryRowKey(msg):
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m model) renderChildWorkflowTree(width int, height int) string {
+	innerWidth := width - 2
+	currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+	if currentHistoryStackItem.childWorkflowTree == nil {
+		return childWorkflowTreeBoxStyle.Width(innerWidth).Height(height).Render("Loading child workflows...")
+	}
+	rows := m.focusedWorkflowState.getChildWorkflowTreeRows()
+	lines := []string{}
+	// Keep the cursor visible by scrolling the window of rows
+	offset := max(0, m.focusedWorkflowState.cursor-height+1)
+	for i := offset; i < len(rows) && i < offset+height; i++ {
+		row := rows[i]
+		info := row.node.workflow
+		statusIcon := statusToStyleMap[info.GetStatus().String()].icon
+		line := row.prefix + statusIcon + " " + info.GetType().GetName() + " " + childWorkflowTreeMutedStyle.Render(info.GetExecution().GetWorkflowId())
+		if row.node.hasMoreChildren {
+			line += childWorkflowTreeMutedStyle.Render(" (limit reached, children not loaded)")
+		}
+		line = lipgloss.NewStyle().MaxWidth(innerWidth).Render(line)
+		if i == m.focusedWorkflowState.cursor {
+			line = SelectedRowStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return childWorkflowTreeBoxStyle.Width(innerWidth).Height(height).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"go.temporal.io/api/temporalproto"
+	"google.golang.org/protobuf/proto"
+)
+
+// ========================================
+// Clipboard and File Output
+// ========================================
+
+// Copies the text to the clipboard using OSC52 so it works over SSH.
+// The local clipboard is also set when one is available.
+func copyToClipboard(text string) error {
+	sequence := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		sequence = sequence.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		sequence = sequence.Screen()
+	}
+	if _, err := sequence.WriteTo(os.Stderr); err != nil {
+		return err
+	}
+	// Not every machine has a local clipboard (e.g. over SSH), OSC52 is enough there
+	_ = clipboard.WriteAll(text)
+	return nil
+}
+
+func protoToPrettyJSON(message proto.Message) (string, error) {
+	data, err := temporalproto.CustomJSONMarshalOptions{Indent: "  "}.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+func sanitizeFileName(name string) string {
+	return strings.Trim(unsafeFileNameCharacters.ReplaceAllString(name, "_"), "_")
+}
+
+// Writes the content to a file in the current directory and returns the path
+func saveToFile(fileName string, content string) (string, error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(workingDir, sanitizeFileName(fileName))
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func yankCmd(label string, text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := copyToClipboard(text); err != nil {
+			return statusMessageMsg{message: fmt.Sprintf("Failed to copy %s: %v", label, err)}
+		}
+		return statusMessageMsg{message: fmt.Sprintf("Copied %s to clipboard", label)}
+	}
+}
+
+func saveToFileCmd(fileName string, content string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := saveToFile(fileName, content)
+		if err != nil {
+			return statusMessageMsg{message: fmt.Sprintf("Failed to save %s: %v", fileName, err)}
+		}
+		return statusMessageMsg{message: fmt.Sprintf("Saved to %s", path)}
+	}
+}
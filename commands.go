@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+)
+
+// ========================================
+// Commands
+// ========================================
+
+// Runs a command given after the global flags, e.g. `kairos-cli --local export-history <workflowId>`
+func runCommand(args []string) error {
+	switch args[0] {
+	case "export-history":
+		return exportHistoryCommand(args[1:])
+	case "view":
+		return viewCommand(args[1:])
+	case "export":
+		return bulkExportCommand(args[1:])
+	}
+	return fmt.Errorf("unknown command %q, available commands: export-history, export, view", args[0])
+}
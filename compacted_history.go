@@ -0,0 +1,391 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.temporal.io/api/common/v1"
+	temporalEnums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflow/v1"
+)
+
+// ========================================
+// Compacted History
+// ========================================
+
+type eventContent struct {
+	eventType string
+	eventData string
+}
+
+type compactHistoryListItem struct {
+	events        []*history.HistoryEvent
+	eventsContent []eventContent
+	icon          string
+	actionType    string
+	rowContent    string
+}
+
+// Compacted history that is built incrementally from batches of events
+type compactedHistory struct {
+	itemsByEventId map[int64]*compactHistoryListItem
+	// Sorted by the first event id, newest first
+	sortedItems []*compactHistoryListItem
+	// Items created by the batch that is being added
+	newItems []*compactHistoryListItem
+	// Set when an out of order event changed the first event of an item that is already sorted
+	needsSort bool
+	// Incremented whenever the items change
+	version int
+}
+
+func newCompactedHistory() *compactedHistory {
+	return &compactedHistory{
+		itemsByEventId: make(map[int64]*compactHistoryListItem),
+		sortedItems:    make([]*compactHistoryListItem, 0),
+	}
+}
+
+func (c *compactedHistory) createItem(eventId int64) *compactHistoryListItem {
+	item := &compactHistoryListItem{events: make([]*history.HistoryEvent, 0)}
+	c.itemsByEventId[eventId] = item
+	c.newItems = append(c.newItems, item)
+	return item
+}
+
+// Returns the item of the action started by eventId. When the history is partial or out of order the first
+// event of the action may be missing, a placeholder item is created so the event still gets a row
+func (c *compactedHistory) getItem(eventId int64, actionType string) *compactHistoryListItem {
+	if item, ok := c.itemsByEventId[eventId]; ok {
+		return item
+	}
+	item := c.createItem(eventId)
+	item.actionType = actionType
+	item.icon = "❔"
+	item.rowContent = "Event " + strconv.FormatInt(eventId, 10) + " missing"
+	return item
+}
+
+// Creates the item of an action from its first event, or fills in the placeholder created by a later event
+func (c *compactedHistory) startItem(historyEvent *history.HistoryEvent, actionType string, icon string, rowContent string) *compactHistoryListItem {
+	item, ok := c.itemsByEventId[historyEvent.GetEventId()]
+	if !ok {
+		item = c.createItem(historyEvent.GetEventId())
+	}
+	item.actionType = actionType
+	item.rowContent = rowContent
+	c.addEvent(item, historyEvent, icon)
+	return item
+}
+
+// Adds an event to an item keeping the events ordered by event id. The icon shows the state of the
+// latest event, so it is only updated when the event is the newest of the item
+func (c *compactedHistory) addEvent(item *compactHistoryListItem, historyEvent *history.HistoryEvent, icon string) {
+	index := len(item.events)
+	for index > 0 && item.events[index-1].GetEventId() > historyEvent.GetEventId() {
+		index--
+	}
+	item.events = slices.Insert(item.events, index, historyEvent)
+	if index == len(item.events)-1 {
+		item.icon = icon
+	}
+	if index == 0 && len(item.events) > 1 {
+		c.needsSort = true
+	}
+}
+
+func (c *compactedHistory) getSortedItems() []*compactHistoryListItem {
+	return c.sortedItems
+}
+
+// Adds the items created by the last batch to the sorted items
+func (c *compactedHistory) updateSortedItems() {
+	newItems := c.newItems
+	c.newItems = nil
+	sort.Slice(newItems, func(i, j int) bool {
+		return newItems[i].events[0].GetEventId() > newItems[j].events[0].GetEventId()
+	})
+	// Events arrive in order, so new items are usually newer than everything that is already sorted
+	if !c.needsSort && (len(c.sortedItems) == 0 || len(newItems) == 0 || newItems[len(newItems)-1].events[0].GetEventId() > c.sortedItems[0].events[0].GetEventId()) {
+		c.sortedItems = append(newItems, c.sortedItems...)
+		return
+	}
+	c.needsSort = false
+	c.sortedItems = append(c.sortedItems, newItems...)
+	sort.Slice(c.sortedItems, func(i, j int) bool {
+		return c.sortedItems[i].events[0].GetEventId() > c.sortedItems[j].events[0].GetEventId()
+	})
+}
+
+func convertDataToPrettyJSON(data []byte) string {
+	var prettyJSON interface{}
+	// Payloads are not always json (e.g. binary or plain text), show them as is
+	if err := json.Unmarshal(data, &prettyJSON); err != nil {
+		return string(data)
+	}
+	prettyJSONBytes, _ := json.MarshalIndent(prettyJSON, "", "  ")
+	return string(prettyJSONBytes)
+}
+
+// Only the first payload is shown, results and inputs are usually a single value
+func (item *compactHistoryListItem) addPayloadsContent(eventType string, payloads *common.Payloads) {
+	if len(payloads.GetPayloads()) == 0 {
+		return
+	}
+	item.addPayloadContent(eventType, payloads.GetPayloads()[0])
+}
+
+func (item *compactHistoryListItem) addPayloadContent(eventType string, payload *common.Payload) {
+	if payload == nil {
+		return
+	}
+	item.eventsContent = append(item.eventsContent, eventContent{eventType: eventType, eventData: convertDataToPrettyJSON(payload.GetData())})
+}
+
+func getSortedPayloadMapKeys(fields map[string]*common.Payload) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Renders search attributes and memos as one "key: value" line per field
+func formatPayloadMap(fields map[string]*common.Payload) string {
+	lines := []string{}
+	for _, key := range getSortedPayloadMapKeys(fields) {
+		lines = append(lines, key+": "+convertDataToPrettyJSON(fields[key].GetData()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func createCompactHistory(historyList []*history.HistoryEvent, pendingActivities []*workflow.PendingActivityInfo) *compactedHistory {
+	compactedHistory := newCompactedHistory()
+	compactedHistory.addEvents(historyList)
+	compactedHistory.applyPendingActivities(pendingActivities)
+	return compactedHistory
+}
+
+// Pending activities change while the workflow runs, so their attempt and last error are applied separately from the events
+func (c *compactedHistory) applyPendingActivities(pendingActivities []*workflow.PendingActivityInfo) {
+	c.version++
+	pendingActivitiesById := make(map[string]*workflow.PendingActivityInfo)
+	for _, pendingActivity := range pendingActivities {
+		pendingActivitiesById[pendingActivity.GetActivityId()] = pendingActivity
+	}
+	for _, compactHistoryItem := range c.itemsByEventId {
+		scheduledEventAttributes := compactHistoryItem.events[0].GetActivityTaskScheduledEventAttributes()
+		if scheduledEventAttributes == nil {
+			continue
+		}
+		compactHistoryItem.rowContent = scheduledEventAttributes.GetActivityType().GetName()
+		eventsContent := []eventContent{}
+		for _, content := range compactHistoryItem.eventsContent {
+			if content.eventType != "Last Error" {
+				eventsContent = append(eventsContent, content)
+			}
+		}
+		if pendingActivity, ok := pendingActivitiesById[scheduledEventAttributes.GetActivityId()]; ok {
+			errorCause := pendingActivity.GetLastFailure().GetCause().GetMessage()
+			eventsContent = append([]eventContent{{eventType: "Last Error", eventData: errorCause}}, eventsContent...)
+			compactHistoryItem.rowContent += " 🔄" + strconv.Itoa(int(pendingActivity.GetAttempt()))
+		}
+		compactHistoryItem.eventsContent = eventsContent
+	}
+}
+
+// Consumes a batch of events. Events that reference an event that is not in the history (partial or out of
+// order histories) get a placeholder row instead of being dropped
+func (c *compactedHistory) addEvents(historyList []*history.HistoryEvent) {
+	c.version++
+	for _, historyEvent := range historyList {
+		eventType := historyEvent.GetEventType()
+		switch eventType {
+		// Activity events
+		// Activity events are special because they have multiple events that are related to each other
+		// Activity events are grouped by the scheduled event id
+		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			attributes := historyEvent.GetActivityTaskScheduledEventAttributes()
+			item := c.startItem(historyEvent, "Activity", "📅", attributes.GetActivityType().GetName())
+			item.addPayloadsContent("Input", attributes.GetInput())
+		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+			item := c.getItem(historyEvent.GetActivityTaskStartedEventAttributes().GetScheduledEventId(), "Activity")
+			c.addEvent(item, historyEvent, "🏃")
+		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+			attributes := historyEvent.GetActivityTaskCompletedEventAttributes()
+			item := c.getItem(attributes.GetScheduledEventId(), "Activity")
+			item.addPayloadsContent("Output", attributes.GetResult())
+			c.addEvent(item, historyEvent, "✅")
+		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+			attributes := historyEvent.GetActivityTaskFailedEventAttributes()
+			item := c.getItem(attributes.GetScheduledEventId(), "Activity")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: formatFailure(attributes.GetFailure())})
+			c.addEvent(item, historyEvent, "❌")
+		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			attributes := historyEvent.GetActivityTaskTimedOutEventAttributes()
+			item := c.getItem(attributes.GetScheduledEventId(), "Activity")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: formatFailure(attributes.GetFailure())})
+			c.addEvent(item, historyEvent, "⏰")
+		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_CANCEL_REQUESTED:
+			item := c.getItem(historyEvent.GetActivityTaskCancelRequestedEventAttributes().GetScheduledEventId(), "Activity")
+			c.addEvent(item, historyEvent, "🚫")
+		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+			item := c.getItem(historyEvent.GetActivityTaskCanceledEventAttributes().GetScheduledEventId(), "Activity")
+			c.addEvent(item, historyEvent, "🚫")
+
+		// Timer events
+		case temporalEnums.EVENT_TYPE_TIMER_STARTED:
+			c.startItem(historyEvent, "Timer", "⏰", historyEvent.GetTimerStartedEventAttributes().GetTimerId())
+		case temporalEnums.EVENT_TYPE_TIMER_FIRED:
+			item := c.getItem(historyEvent.GetTimerFiredEventAttributes().GetStartedEventId(), "Timer")
+			c.addEvent(item, historyEvent, "🔥")
+		case temporalEnums.EVENT_TYPE_TIMER_CANCELED:
+			item := c.getItem(historyEvent.GetTimerCanceledEventAttributes().GetStartedEventId(), "Timer")
+			c.addEvent(item, historyEvent, "🚫")
+
+		// Child workflow events
+		case temporalEnums.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+			attributes := historyEvent.GetStartChildWorkflowExecutionInitiatedEventAttributes()
+			item := c.startItem(historyEvent, "Child Workflow", "👶🏃", attributes.GetWorkflowType().GetName())
+			item.addPayloadsContent("Input", attributes.GetInput())
+		case temporalEnums.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_FAILED:
+			attributes := historyEvent.GetStartChildWorkflowExecutionFailedEventAttributes()
+			item := c.getItem(attributes.GetInitiatedEventId(), "Child Workflow")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: "Failed to start: " + attributes.GetCause().String()})
+			c.addEvent(item, historyEvent, "❌👶")
+		case temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
+			item := c.getItem(historyEvent.GetChildWorkflowExecutionStartedEventAttributes().GetInitiatedEventId(), "Child Workflow")
+			c.addEvent(item, historyEvent, "🏃👶")
+		case temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:
+			attributes := historyEvent.GetChildWorkflowExecutionCompletedEventAttributes()
+			item := c.getItem(attributes.GetInitiatedEventId(), "Child Workflow")
+			item.addPayloadsContent("Output", attributes.GetResult())
+			c.addEvent(item, historyEvent, "✅👶")
+		case temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
+			attributes := historyEvent.GetChildWorkflowExecutionFailedEventAttributes()
+			item := c.getItem(attributes.GetInitiatedEventId(), "Child Workflow")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: formatFailure(attributes.GetFailure())})
+			c.addEvent(item, historyEvent, "❌👶")
+		case temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TIMED_OUT:
+			attributes := historyEvent.GetChildWorkflowExecutionTimedOutEventAttributes()
+			item := c.getItem(attributes.GetInitiatedEventId(), "Child Workflow")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: formatTimeout("Child workflow", attributes.GetRetryState())})
+			c.addEvent(item, historyEvent, "⏰👶")
+		case temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED:
+			attributes := historyEvent.GetChildWorkflowExecutionCanceledEventAttributes()
+			item := c.getItem(attributes.GetInitiatedEventId(), "Child Workflow")
+			item.addPayloadsContent("Details", attributes.GetDetails())
+			c.addEvent(item, historyEvent, "🚫👶")
+		case temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TERMINATED:
+			item := c.getItem(historyEvent.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventId(), "Child Workflow")
+			c.addEvent(item, historyEvent, "💀👶")
+
+		// Signals sent to other workflows
+		case temporalEnums.EVENT_TYPE_SIGNAL_EXTERNAL_WORKFLOW_EXECUTION_INITIATED:
+			attributes := historyEvent.GetSignalExternalWorkflowExecutionInitiatedEventAttributes()
+			item := c.startItem(historyEvent, "External Signal", "📤", attributes.GetSignalName()+" → "+attributes.GetWorkflowExecution().GetWorkflowId())
+			item.addPayloadsContent("Input", attributes.GetInput())
+		case temporalEnums.EVENT_TYPE_EXTERNAL_WORKFLOW_EXECUTION_SIGNALED:
+			item := c.getItem(historyEvent.GetExternalWorkflowExecutionSignaledEventAttributes().GetInitiatedEventId(), "External Signal")
+			c.addEvent(item, historyEvent, "✅")
+		case temporalEnums.EVENT_TYPE_SIGNAL_EXTERNAL_WORKFLOW_EXECUTION_FAILED:
+			attributes := historyEvent.GetSignalExternalWorkflowExecutionFailedEventAttributes()
+			item := c.getItem(attributes.GetInitiatedEventId(), "External Signal")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: attributes.GetCause().String()})
+			c.addEvent(item, historyEvent, "❌")
+
+		// Cancellations requested for other workflows
+		case temporalEnums.EVENT_TYPE_REQUEST_CANCEL_EXTERNAL_WORKFLOW_EXECUTION_INITIATED:
+			attributes := historyEvent.GetRequestCancelExternalWorkflowExecutionInitiatedEventAttributes()
+			item := c.startItem(historyEvent, "Cancel External", "🛑", attributes.GetWorkflowExecution().GetWorkflowId())
+			if attributes.GetReason() != "" {
+				item.eventsContent = append(item.eventsContent, eventContent{eventType: "Reason", eventData: attributes.GetReason()})
+			}
+		case temporalEnums.EVENT_TYPE_EXTERNAL_WORKFLOW_EXECUTION_CANCEL_REQUESTED:
+			item := c.getItem(historyEvent.GetExternalWorkflowExecutionCancelRequestedEventAttributes().GetInitiatedEventId(), "Cancel External")
+			c.addEvent(item, historyEvent, "✅")
+		case temporalEnums.EVENT_TYPE_REQUEST_CANCEL_EXTERNAL_WORKFLOW_EXECUTION_FAILED:
+			attributes := historyEvent.GetRequestCancelExternalWorkflowExecutionFailedEventAttributes()
+			item := c.getItem(attributes.GetInitiatedEventId(), "Cancel External")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: attributes.GetCause().String()})
+			c.addEvent(item, historyEvent, "❌")
+
+		// Nexus operation events are grouped by the scheduled event id
+		case temporalEnums.EVENT_TYPE_NEXUS_OPERATION_SCHEDULED:
+			attributes := historyEvent.GetNexusOperationScheduledEventAttributes()
+			item := c.startItem(historyEvent, "Nexus Operation", "📅", attributes.GetService()+"/"+attributes.GetOperation())
+			item.addPayloadContent("Input", attributes.GetInput())
+		case temporalEnums.EVENT_TYPE_NEXUS_OPERATION_STARTED:
+			item := c.getItem(historyEvent.GetNexusOperationStartedEventAttributes().GetScheduledEventId(), "Nexus Operation")
+			c.addEvent(item, historyEvent, "🏃")
+		case temporalEnums.EVENT_TYPE_NEXUS_OPERATION_COMPLETED:
+			attributes := historyEvent.GetNexusOperationCompletedEventAttributes()
+			item := c.getItem(attributes.GetScheduledEventId(), "Nexus Operation")
+			item.addPayloadContent("Output", attributes.GetResult())
+			c.addEvent(item, historyEvent, "✅")
+		case temporalEnums.EVENT_TYPE_NEXUS_OPERATION_FAILED:
+			attributes := historyEvent.GetNexusOperationFailedEventAttributes()
+			item := c.getItem(attributes.GetScheduledEventId(), "Nexus Operation")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: formatFailure(attributes.GetFailure())})
+			c.addEvent(item, historyEvent, "❌")
+		case temporalEnums.EVENT_TYPE_NEXUS_OPERATION_TIMED_OUT:
+			attributes := historyEvent.GetNexusOperationTimedOutEventAttributes()
+			item := c.getItem(attributes.GetScheduledEventId(), "Nexus Operation")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: formatFailure(attributes.GetFailure())})
+			c.addEvent(item, historyEvent, "⏰")
+		case temporalEnums.EVENT_TYPE_NEXUS_OPERATION_CANCEL_REQUESTED:
+			item := c.getItem(historyEvent.GetNexusOperationCancelRequestedEventAttributes().GetScheduledEventId(), "Nexus Operation")
+			c.addEvent(item, historyEvent, "🚫")
+		case temporalEnums.EVENT_TYPE_NEXUS_OPERATION_CANCELED:
+			attributes := historyEvent.GetNexusOperationCanceledEventAttributes()
+			item := c.getItem(attributes.GetScheduledEventId(), "Nexus Operation")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: formatFailure(attributes.GetFailure())})
+			c.addEvent(item, historyEvent, "🚫")
+
+		// Markers (local activities, side effects, versions...)
+		case temporalEnums.EVENT_TYPE_MARKER_RECORDED:
+			marker := decodeMarker(historyEvent.GetMarkerRecordedEventAttributes())
+			item := c.startItem(historyEvent, marker.actionType, marker.icon, marker.rowContent)
+			item.eventsContent = append(item.eventsContent, marker.eventsContent...)
+
+		// Search attributes and memo updates
+		case temporalEnums.EVENT_TYPE_UPSERT_WORKFLOW_SEARCH_ATTRIBUTES:
+			fields := historyEvent.GetUpsertWorkflowSearchAttributesEventAttributes().GetSearchAttributes().GetIndexedFields()
+			item := c.startItem(historyEvent, eventType.String(), "🔎", strings.Join(getSortedPayloadMapKeys(fields), ", "))
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Search Attributes", eventData: formatPayloadMap(fields)})
+		case temporalEnums.EVENT_TYPE_WORKFLOW_PROPERTIES_MODIFIED:
+			fields := historyEvent.GetWorkflowPropertiesModifiedEventAttributes().GetUpsertedMemo().GetFields()
+			item := c.startItem(historyEvent, eventType.String(), "📝", strings.Join(getSortedPayloadMapKeys(fields), ", "))
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Memo", eventData: formatPayloadMap(fields)})
+
+		// General workflow events
+		case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+			item := c.startItem(historyEvent, eventType.String(), "🚀", "Workflow started")
+			item.addPayloadsContent("Input", historyEvent.GetWorkflowExecutionStartedEventAttributes().GetInput())
+		case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+			item := c.startItem(historyEvent, eventType.String(), "✅", "")
+			item.addPayloadsContent("Output", historyEvent.GetWorkflowExecutionCompletedEventAttributes().GetResult())
+		case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+			attributes := historyEvent.GetWorkflowExecutionFailedEventAttributes()
+			item := c.startItem(historyEvent, eventType.String(), "❌", attributes.GetFailure().GetMessage())
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: formatFailure(attributes.GetFailure())})
+		case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+			item := c.startItem(historyEvent, eventType.String(), "⏰", "")
+			item.eventsContent = append(item.eventsContent, eventContent{eventType: "Error", eventData: formatTimeout("Workflow", historyEvent.GetWorkflowExecutionTimedOutEventAttributes().GetRetryState())})
+		case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+			c.startItem(historyEvent, eventType.String(), "🛜", historyEvent.GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+
+		default:
+			// Workflow task events are not shown in the compact view
+			if c.itemsByEventId[historyEvent.GetEventId()] == nil && !strings.Contains(eventType.String(), "WorkflowTask") {
+				c.startItem(historyEvent, eventType.String(), "", "")
+			}
+		}
+	}
+	c.updateSortedItems()
+}
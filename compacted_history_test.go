@@ -0,0 +1,135 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"go.temporal.io/api/history/v1"
+)
+
+// Synthetic history written with historyToJSON, not recorded from a real run. It has an activity, a timer,
+// a signal, markers, a nexus operation and two child workflows
+const ORDER_WORKFLOW_HISTORY_FIXTURE = "testdata/order_workflow_history.json"
+
+var orderWorkflowRows = []string{
+	"✅ ",
+	"💀👶 NotifyWorkflow",
+	"🚫👶 PackWorkflow",
+	"❌ invoices/create",
+	"🎲 1",
+	"🔖 new-shipping v2",
+	"🛜 shipped",
+	"🔥 wait-for-shipping",
+	"✅ ChargeCard",
+	"🚀 Workflow started",
+}
+
+func readHistoryFixture(t *testing.T, path string) []*history.HistoryEvent {
+	t.Helper()
+	historyFixture := &history.History{}
+	if err := readProtoJSONFile(path, historyFixture); err != nil {
+		t.Fatal(err)
+	}
+	return historyFixture.GetEvents()
+}
+
+func getCompactHistoryRows(c *compactedHistory) []string {
+	rows := []string{}
+	for _, item := range c.getSortedItems() {
+		rows = append(rows, item.icon+" "+item.rowContent)
+	}
+	return rows
+}
+
+func TestCompactedHistoryRows(t *testing.T) {
+	events := readHistoryFixture(t, ORDER_WORKFLOW_HISTORY_FIXTURE)
+	reversedEvents := slices.Clone(events)
+	slices.Reverse(reversedEvents)
+
+	testCases := []struct {
+		name string
+		// Every page is added with a separate addEvents call, like the pages loaded by the focused view
+		pages [][]*history.HistoryEvent
+		rows  []string
+	}{
+		{
+			name:  "full history",
+			pages: [][]*history.HistoryEvent{events},
+			rows:  orderWorkflowRows,
+		},
+		{
+			name:  "partial first page",
+			pages: [][]*history.HistoryEvent{events[:6]},
+			rows:  []string{"🏃 ChargeCard", "🚀 Workflow started"},
+		},
+		{
+			name:  "out of order events",
+			pages: [][]*history.HistoryEvent{reversedEvents},
+			rows:  orderWorkflowRows,
+		},
+		{
+			name:  "missing start event",
+			pages: [][]*history.HistoryEvent{events[6:10]},
+			rows:  []string{"🛜 shipped", "🔥 wait-for-shipping", "✅ Event 5 missing"},
+		},
+		{
+			name:  "pages split inside actions",
+			pages: [][]*history.HistoryEvent{events[:6], events[6:14], events[14:]},
+			rows:  orderWorkflowRows,
+		},
+		{
+			name:  "later page added first",
+			pages: [][]*history.HistoryEvent{events[6:], events[:6]},
+			rows:  orderWorkflowRows,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			compactHistory := newCompactedHistory()
+			for _, page := range testCase.pages {
+				compactHistory.addEvents(page)
+			}
+			compactHistory.applyPendingActivities(nil)
+			if rows := getCompactHistoryRows(compactHistory); !slices.Equal(rows, testCase.rows) {
+				t.Errorf("rows = %q, want %q", rows, testCase.rows)
+			}
+		})
+	}
+}
+
+func TestCreateCompactHistoryGroupsEvents(t *testing.T) {
+	events := readHistoryFixture(t, ORDER_WORKFLOW_HISTORY_FIXTURE)
+	compactHistory := createCompactHistory(events, nil)
+
+	// Action types and event ids by the first event of each row
+	testCases := []struct {
+		firstEventId int64
+		actionType   string
+		eventIds     []int64
+	}{
+		{firstEventId: 5, actionType: "Activity", eventIds: []int64{5, 6, 7}},
+		{firstEventId: 8, actionType: "Timer", eventIds: []int64{8, 10}},
+		{firstEventId: 9, actionType: "WorkflowExecutionSignaled", eventIds: []int64{9}},
+		{firstEventId: 11, actionType: "Version", eventIds: []int64{11}},
+		{firstEventId: 12, actionType: "Side Effect", eventIds: []int64{12}},
+		{firstEventId: 13, actionType: "Nexus Operation", eventIds: []int64{13, 14, 15}},
+		{firstEventId: 16, actionType: "Child Workflow", eventIds: []int64{16, 17, 20}},
+		{firstEventId: 18, actionType: "Child Workflow", eventIds: []int64{18, 19, 21}},
+	}
+
+	for _, testCase := range testCases {
+		item := compactHistory.itemsByEventId[testCase.firstEventId]
+		if item == nil {
+			t.Errorf("no row for event %d", testCase.firstEventId)
+			continue
+		}
+		eventIds := []int64{}
+		for _, event := range item.events {
+			eventIds = append(eventIds, event.GetEventId())
+		}
+		if item.actionType != testCase.actionType || !slices.Equal(eventIds, testCase.eventIds) {
+			t.Errorf("event %d: action type %q with events %v, want %q with events %v", testCase.firstEventId, item.actionType, eventIds, testCase.actionType, testCase.eventIds)
+		}
+	}
+}
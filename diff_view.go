@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	temporalEnums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflow/v1"
+)
+
+// ========================================
+// Diff View
+// ========================================
+
+// Steps are aligned with a longest common subsequence, the steps after this limit are not compared
+const DIFF_MAX_STEPS = 2000
+
+// Only these rows are compared, the other rows (signals, workflow task events...) depend on timing
+var diffStepActionTypes = map[string]bool{
+	"Activity":            true,
+	"Local Activity":      true,
+	"Timer":               true,
+	"Child Workflow":      true,
+	"Nexus Operation":     true,
+	"Side Effect":         true,
+	"Mutable Side Effect": true,
+	"Version":             true,
+	"Patch":               true,
+}
+
+// Contents that are compared between two matching steps
+var diffContentTypes = []string{"Input", "Output", "Error"}
+
+var diffChangedRowStyle = lipgloss.NewStyle().Padding(0, 0).Background(lipgloss.Color("#553300"))
+var diffMissingRowStyle = lipgloss.NewStyle().Padding(0, 0).Background(lipgloss.Color("#550000"))
+var diffBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+
+// Attempt decorations (e.g. " 🔄3") are not part of the step name
+var diffRowContentSuffix = regexp.MustCompile(` 🔄\d+$`)
+
+type DiffKeyMap struct {
+	Up                 key.Binding
+	Down               key.Binding
+	NextDifference     key.Binding
+	PreviousDifference key.Binding
+	Back               key.Binding
+	Exit               key.Binding
+}
+
+var DiffViewKeyMap = DiffKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("↑/k", "move up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓/j", "move down"),
+	),
+	NextDifference: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next difference"),
+	),
+	PreviousDifference: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous difference"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back to list"),
+	),
+	Exit: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "exit"),
+	),
+}
+
+func (k DiffKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.NextDifference, k.PreviousDifference, k.Back, k.Exit}
+}
+
+func (k DiffKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+type diffStep struct {
+	item *compactHistoryListItem
+	// Used to align the steps of both executions, e.g. "Activity ChargeCard"
+	key string
+}
+
+// A row of the diff, one of the steps is nil when the step only exists in one execution
+type diffRow struct {
+	left    *diffStep
+	right   *diffStep
+	changed bool
+}
+
+type diffViewState struct {
+	active  bool
+	loading bool
+	left    *workflow.WorkflowExecutionInfo
+	right   *workflow.WorkflowExecutionInfo
+	rows    []diffRow
+	// Set when one of the executions has more than DIFF_MAX_STEPS steps
+	truncated bool
+	cursor    int
+	keys      DiffKeyMap
+	// Histories loaded for a diff that was closed or replaced are ignored
+	session int
+}
+
+type diffLoadedMsg struct {
+	session   int
+	rows      []diffRow
+	truncated bool
+	err       error
+}
+
+func getDiffSteps(historyList []*history.HistoryEvent) []*diffStep {
+	compactHistory := createCompactHistory(historyList, nil)
+	sortedItems := compactHistory.getSortedItems()
+	steps := []*diffStep{}
+	// Sorted items are newest first, steps are compared in the order they happened
+	for i := len(sortedItems) - 1; i >= 0; i-- {
+		item := sortedItems[i]
+		if !diffStepActionTypes[item.actionType] {
+			continue
+		}
+		rowContent := diffRowContentSuffix.ReplaceAllString(item.rowContent, "")
+		steps = append(steps, &diffStep{item: item, key: item.actionType + " " + rowContent})
+	}
+	return steps
+}
+
+func getDiffStepContent(step *diffStep, contentType string) string {
+	for _, content := range step.item.eventsContent {
+		if content.eventType == contentType {
+			return content.eventData
+		}
+	}
+	return ""
+}
+
+// Two matching steps differ when they ended differently or their payloads differ
+func isDiffStepChanged(left *diffStep, right *diffStep) bool {
+	if left.item.icon != right.item.icon {
+		return true
+	}
+	for _, contentType := range diffContentTypes {
+		if getDiffStepContent(left, contentType) != getDiffStepContent(right, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Aligns the steps with the longest common subsequence of their keys
+func alignDiffSteps(leftSteps []*diffStep, rightSteps []*diffStep) []diffRow {
+	lcs := make([][]int32, len(leftSteps)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(rightSteps)+1)
+	}
+	for i := len(leftSteps) - 1; i >= 0; i-- {
+		for j := len(rightSteps) - 1; j >= 0; j-- {
+			if leftSteps[i].key == rightSteps[j].key {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	rows := []diffRow{}
+	i, j := 0, 0
+	for i < len(leftSteps) || j < len(rightSteps) {
+		switch {
+		case i < len(leftSteps) && j < len(rightSteps) && leftSteps[i].key == rightSteps[j].key:
+			rows = append(rows, diffRow{left: leftSteps[i], right: rightSteps[j], changed: isDiffStepChanged(leftSteps[i], rightSteps[j])})
+			i++
+			j++
+		case j >= len(rightSteps) || (i < len(leftSteps) && lcs[i+1][j] >= lcs[i][j+1]):
+			rows = append(rows, diffRow{left: leftSteps[i], changed: true})
+			i++
+		default:
+			rows = append(rows, diffRow{right: rightSteps[j], changed: true})
+			j++
+		}
+	}
+	return rows
+}
+
+func (m *model) getFullHistory(workflowId string, runId string) ([]*history.HistoryEvent, error) {
+	temporalClient, _ := m.getTemporalClient()
+	historyIterator := temporalClient.GetWorkflowHistory(context.Background(), workflowId, runId, false, temporalEnums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	historyEvents := []*history.HistoryEvent{}
+	for historyIterator.HasNext() {
+		historyEvent, err := historyIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		historyEvents = append(historyEvents, historyEvent)
+	}
+	return historyEvents, nil
+}
+
+func (m *model) loadDiffCmd(session int, left *workflow.WorkflowExecutionInfo, right *workflow.WorkflowExecutionInfo) tea.Cmd {
+	return func() tea.Msg {
+		leftHistory, err := m.getFullHistory(left.GetExecution().GetWorkflowId(), left.GetExecution().GetRunId())
+		if err != nil {
+			return diffLoadedMsg{session: session, err: err}
+		}
+		rightHistory, err := m.getFullHistory(right.GetExecution().GetWorkflowId(), right.GetExecution().GetRunId())
+		if err != nil {
+			return diffLoadedMsg{session: session, err: err}
+		}
+		leftSteps := getDiffSteps(leftHistory)
+		rightSteps := getDiffSteps(rightHistory)
+		truncated := len(leftSteps) > DIFF_MAX_STEPS || len(rightSteps) > DIFF_MAX_STEPS
+		leftSteps = leftSteps[:min(len(leftSteps), DIFF_MAX_STEPS)]
+		rightSteps = rightSteps[:min(len(rightSteps), DIFF_MAX_STEPS)]
+		return diffLoadedMsg{session: session, rows: alignDiffSteps(leftSteps, rightSteps), truncated: truncated}
+	}
+}
+
+// Diffs the two selected workflows, the one that started first is shown on the left
+func (m model) openDiffView() (model, tea.Cmd) {
+	if len(m.selected) != 2 {
+		return m, statusMessageCmd(fmt.Sprintf("Select exactly 2 workflows to diff (%d selected)", len(m.selected)))
+	}
+	executions := []*workflow.WorkflowExecutionInfo{}
+	for _, execution := range m.selected {
+		executions = append(executions, execution)
+	}
+	if executions[1].GetStartTime().AsTime().Before(executions[0].GetStartTime().AsTime()) {
+		executions[0], executions[1] = executions[1], executions[0]
+	}
+	m.diffViewState.active = true
+	m.diffViewState.loading = true
+	m.diffViewState.left = executions[0]
+	m.diffViewState.right = executions[1]
+	m.diffViewState.rows = nil
+	m.diffViewState.cursor = 0
+	m.diffViewState.session++
+	return m, m.loadDiffCmd(m.diffViewState.session, executions[0], executions[1])
+}
+
+func (m *diffViewState) jumpToDifference(direction int) {
+	for i := m.cursor + direction; i >= 0 && i < len(m.rows); i += direction {
+		if m.rows[i].changed {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+func (m model) updateDiffView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := m.diffViewState.keys
+	switch {
+	case key.Matches(msg, keys.Exit):
+		return m, tea.Quit
+	case key.Matches(msg, keys.Back):
+		m.diffViewState.active = false
+		tickCmd := m.resumeRelativeTimeTickCmd()
+		return m, tickCmd
+	case key.Matches(msg, keys.Up):
+		if m.diffViewState.cursor > 0 {
+			m.diffViewState.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.diffViewState.cursor < len(m.diffViewState.rows)-1 {
+			m.diffViewState.cursor++
+		}
+	case key.Matches(msg, keys.NextDifference):
+		m.diffViewState.jumpToDifference(1)
+	case key.Matches(msg, keys.PreviousDifference):
+		m.diffViewState.jumpToDifference(-1)
+	}
+	return m, nil
+}
+
+func renderDiffStep(step *diffStep, width int) string {
+	if step == nil {
+		return lipgloss.NewStyle().Width(width).Render("")
+	}
+	return lipgloss.NewStyle().Width(width).MaxWidth(width).Render(step.item.icon + " " + step.key)
+}
+
+func renderDiffStepDetails(step *diffStep, width int, height int) string {
+	if step == nil {
+		return diffBoxStyle.Width(width - 2).Height(height).Render("Step missing in this execution")
+	}
+	sections := []string{step.item.icon + " " + step.key}
+	for _, contentType := range diffContentTypes {
+		if content := getDiffStepContent(step, contentType); content != "" {
+			sections = append(sections, contentType+":\n"+content)
+		}
+	}
+	return diffBoxStyle.Width(width - 2).Height(height).MaxHeight(height + 2).Render(strings.Join(sections, "\n"))
+}
+
+func formatDiffExecutionTitle(execution *workflow.WorkflowExecutionInfo) string {
+	statusIcon := statusToStyleMap[execution.GetStatus().String()].icon
+	return statusIcon + " " + execution.GetExecution().GetWorkflowId() + " (" + shortRunId(execution.GetExecution().GetRunId()) + ")"
+}
+
+func (m model) renderDiffView() string {
+	columnWidth := m.viewport.Width / 2
+	title := HeaderStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(columnWidth).MaxWidth(columnWidth).Render(formatDiffExecutionTitle(m.diffViewState.left)),
+		lipgloss.NewStyle().Width(columnWidth).MaxWidth(columnWidth).Render(formatDiffExecutionTitle(m.diffViewState.right)),
+	))
+	footer := m.help.ShortHelpView(m.diffViewState.keys.ShortHelp())
+	if m.statusMessage != "" {
+		footer = m.statusMessage
+	}
+	if m.diffViewState.loading {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading histories...", footer)
+	}
+	changedCount := 0
+	for _, row := range m.diffViewState.rows {
+		if row.changed {
+			changedCount++
+		}
+	}
+	summary := fmt.Sprintf("%d steps, %d differences", len(m.diffViewState.rows), changedCount)
+	if m.diffViewState.truncated {
+		summary += fmt.Sprintf(" (only the first %d steps are compared)", DIFF_MAX_STEPS)
+	}
+
+	bodyHeight := m.viewport.Height - lipgloss.Height(title) - lipgloss.Height(footer) - 1
+	listHeight := max(bodyHeight/2, 1)
+	detailsHeight := max(bodyHeight-listHeight-2, 1)
+
+	// Keep the cursor visible by scrolling the window of rows
+	offset := max(0, m.diffViewState.cursor-listHeight+1)
+	lines := []string{}
+	for i := offset; i < len(m.diffViewState.rows) && i < offset+listHeight; i++ {
+		row := m.diffViewState.rows[i]
+		line := renderDiffStep(row.left, columnWidth) + renderDiffStep(row.right, columnWidth)
+		switch {
+		case i == m.diffViewState.cursor:
+			line = SelectedRowStyle.Render(line)
+		case row.left == nil || row.right == nil:
+			line = diffMissingRowStyle.Render(line)
+		case row.changed:
+			line = diffChangedRowStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	list := lipgloss.NewStyle().Height(listHeight).Render(strings.Join(lines, "\n"))
+
+	details := ""
+	if m.diffViewState.cursor < len(m.diffViewState.rows) {
+		row := m.diffViewState.rows[m.diffViewState.cursor]
+		details = lipgloss.JoinHorizontal(lipgloss.Top, renderDiffStepDetails(row.left, columnWidth, detailsHeight), renderDiffStepDetails(row.right, columnWidth, detailsHeight))
+	}
+	return lipgloss.JoinVertical(lipgloss.Top, title, summary, list, details, footer)
+}
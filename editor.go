@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ========================================
+// External Editor
+// ========================================
+
+type editorClosedMsg struct {
+	path string
+	err  error
+}
+
+// Uses $EDITOR, then $PAGER and falls back to less
+func getEditorCommand(path string) *exec.Cmd {
+	editor := strings.TrimSpace(os.Getenv("EDITOR"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("PAGER"))
+	}
+	if editor == "" {
+		editor = "less"
+	}
+	// The editor can contain arguments (e.g. "code --wait")
+	editorParts := strings.Fields(editor)
+	args := append(editorParts[1:], path)
+	return exec.Command(editorParts[0], args...)
+}
+
+// Writes the content to a temp file and suspends the program while the editor is open
+func openInEditorCmd(fileNamePrefix string, content string) tea.Cmd {
+	return editInEditorCmd(fileNamePrefix, content, func(path string, err error) tea.Msg {
+		return editorClosedMsg{path: path, err: err}
+	})
+}
+
+// Same as openInEditorCmd but lets the caller read the edited file, the caller has to remove it
+func editInEditorCmd(fileNamePrefix string, content string, onClose func(path string, err error) tea.Msg) tea.Cmd {
+	// The temp file is written in the command so Update does not block on the disk
+	return func() tea.Msg {
+		file, err := os.CreateTemp("", sanitizeFileName(fileNamePrefix)+"-*.json")
+		if err != nil {
+			return statusMessageMsg{message: fmt.Sprintf("Failed to create temp file: %v", err)}
+		}
+		defer file.Close()
+		path := file.Name()
+		if _, err := file.WriteString(content); err != nil {
+			os.Remove(path)
+			return statusMessageMsg{message: fmt.Sprintf("Failed to write temp file: %v", err)}
+		}
+		// ExecProcess only builds the message that tells the program to run the editor
+		return tea.ExecProcess(getEditorCommand(path), func(err error) tea.Msg {
+			return onClose(path, err)
+		})()
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.temporal.io/api/common/v1"
+	temporalEnums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/failure/v1"
+)
+
+// ========================================
+// Failure Rendering
+// ========================================
+
+// Failures can be encoded by the SDK failure converter, in that case the message and
+// stack trace live in the encoded attributes
+type encodedFailureAttributes struct {
+	Message    string `json:"message"`
+	StackTrace string `json:"stack_trace"`
+}
+
+func getFailureType(f *failure.Failure) string {
+	switch {
+	case f.GetApplicationFailureInfo() != nil:
+		if f.GetApplicationFailureInfo().GetType() != "" {
+			return "ApplicationFailure (" + f.GetApplicationFailureInfo().GetType() + ")"
+		}
+		return "ApplicationFailure"
+	case f.GetTimeoutFailureInfo() != nil:
+		return "TimeoutFailure (" + f.GetTimeoutFailureInfo().GetTimeoutType().String() + ")"
+	case f.GetCanceledFailureInfo() != nil:
+		return "CanceledFailure"
+	case f.GetTerminatedFailureInfo() != nil:
+		return "TerminatedFailure"
+	case f.GetServerFailureInfo() != nil:
+		return "ServerFailure"
+	case f.GetResetWorkflowFailureInfo() != nil:
+		return "ResetWorkflowFailure"
+	case f.GetActivityFailureInfo() != nil:
+		return "ActivityFailure (" + f.GetActivityFailureInfo().GetActivityType().GetName() + ")"
+	case f.GetChildWorkflowExecutionFailureInfo() != nil:
+		return "ChildWorkflowFailure (" + f.GetChildWorkflowExecutionFailureInfo().GetWorkflowType().GetName() + ")"
+	case f.GetNexusOperationExecutionFailureInfo() != nil:
+		return "NexusOperationFailure"
+	case f.GetNexusHandlerFailureInfo() != nil:
+		return "NexusHandlerFailure"
+	}
+	return "Failure"
+}
+
+func isFailureNonRetryable(f *failure.Failure) bool {
+	return f.GetApplicationFailureInfo().GetNonRetryable() || f.GetServerFailureInfo().GetNonRetryable()
+}
+
+func formatPayloads(payloads *common.Payloads) string {
+	formattedPayloads := []string{}
+	for _, payload := range payloads.GetPayloads() {
+		formattedPayloads = append(formattedPayloads, convertDataToPrettyJSON(payload.GetData()))
+	}
+	return strings.Join(formattedPayloads, "\n")
+}
+
+func indentText(text string, indent string) string {
+	return indent + strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", "\n"+indent)
+}
+
+// Renders the failure and all of its causes as an indented tree
+func formatFailure(f *failure.Failure) string {
+	lines := []string{}
+	depth := 0
+	for current := f; current != nil; current = current.GetCause() {
+		message := current.GetMessage()
+		stackTrace := current.GetStackTrace()
+		if current.GetEncodedAttributes() != nil {
+			var encodedAttributes encodedFailureAttributes
+			if err := json.Unmarshal(current.GetEncodedAttributes().GetData(), &encodedAttributes); err == nil {
+				message = encodedAttributes.Message
+				stackTrace = encodedAttributes.StackTrace
+			}
+		}
+		indent := strings.Repeat("  ", depth)
+		if depth > 0 {
+			lines = append(lines, indent+"Caused by:")
+		}
+		lines = append(lines, indent+"Message: "+message)
+		lines = append(lines, indent+"Type: "+getFailureType(current))
+		if current.GetSource() != "" {
+			lines = append(lines, indent+"Source: "+current.GetSource())
+		}
+		if isFailureNonRetryable(current) {
+			lines = append(lines, indent+"Non-retryable: true")
+		}
+		if retryState := getFailureRetryState(current); retryState != temporalEnums.RETRY_STATE_UNSPECIFIED {
+			lines = append(lines, indent+"Retry state: "+retryState.String())
+		}
+		if details := current.GetApplicationFailureInfo().GetDetails(); details != nil {
+			lines = append(lines, indent+"Details:", indentText(formatPayloads(details), indent+"  "))
+		}
+		if details := current.GetTimeoutFailureInfo().GetLastHeartbeatDetails(); details != nil {
+			lines = append(lines, indent+"Last heartbeat details:", indentText(formatPayloads(details), indent+"  "))
+		}
+		if stackTrace != "" {
+			lines = append(lines, indent+"Stack trace:", indentText(stackTrace, indent+"  "))
+		}
+		depth++
+	}
+	return strings.Join(lines, "\n")
+}
+
+func getFailureRetryState(f *failure.Failure) temporalEnums.RetryState {
+	if f.GetActivityFailureInfo() != nil {
+		return f.GetActivityFailureInfo().GetRetryState()
+	}
+	return f.GetChildWorkflowExecutionFailureInfo().GetRetryState()
+}
+
+// Used for timeouts that don't carry a failure (e.g. workflow execution timed out)
+func formatTimeout(timeoutName string, retryState temporalEnums.RetryState) string {
+	return fmt.Sprintf("Message: %s timed out\nRetry state: %s", timeoutName, retryState.String())
+}
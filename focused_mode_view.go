@@ -1,10 +1,10 @@
 package main
 
 import (
-	"encoding/json"
-	"sort"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,16 +12,42 @@ import (
 	"github.com/charmbracelet/lipgloss/table"
 	temporalEnums "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/history/v1"
-	"go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 )
 
 type FocusedKeyMap struct {
-	Up                 key.Binding
-	Down               key.Binding
-	Exit               key.Binding
-	Back               key.Binding
-	FocusChildWorkflow key.Binding
+	Up                      key.Binding
+	Down                    key.Binding
+	Exit                    key.Binding
+	Back                    key.Binding
+	FocusChildWorkflow      key.Binding
+	NextPayload             key.Binding
+	YankWorkflowId          key.Binding
+	YankRunId               key.Binding
+	YankRowJson             key.Binding
+	YankPayload             key.Binding
+	SavePayload             key.Binding
+	OpenPayload             key.Binding
+	OpenHistory             key.Binding
+	ExportHistory           key.Binding
+	ToggleRawEvents         key.Binding
+	ToggleTimeline          key.Binding
+	ToggleChildTree         key.Binding
+	TogglePendingActivities key.Binding
+	TogglePauseActivity     key.Binding
+	ResetActivity           key.Binding
+	EditActivityOptions     key.Binding
+	FocusParent             key.Binding
+	FocusPreviousRun        key.Binding
+	FocusNextRun            key.Binding
+	FocusFirstRun           key.Binding
+	ToggleFollow            key.Binding
+	ToggleDetails           key.Binding
+	SearchHistory           key.Binding
+	NextMatch               key.Binding
+	PreviousMatch           key.Binding
+	ToggleSearchFilter      key.Binding
+	Help                    key.Binding
 }
 
 var FocusedModeKeyMap = FocusedKeyMap{
@@ -45,53 +71,413 @@ var FocusedModeKeyMap = FocusedKeyMap{
 		key.WithKeys("ctrl+c"),
 		key.WithHelp("ctrl+c", "exit"),
 	),
+	NextPayload: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "select next payload"),
+	),
+	YankWorkflowId: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy workflow id"),
+	),
+	YankRunId: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy run id"),
+	),
+	YankRowJson: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "copy row events as json"),
+	),
+	YankPayload: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "copy payload"),
+	),
+	SavePayload: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "save payload to file"),
+	),
+	OpenPayload: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "open payload in $EDITOR"),
+	),
+	OpenHistory: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "open history in $EDITOR"),
+	),
+	ExportHistory: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "export history for replay"),
+	),
+	ToggleRawEvents: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "toggle raw events"),
+	),
+	ToggleTimeline: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "toggle timeline"),
+	),
+	ToggleChildTree: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "toggle child workflow tree"),
+	),
+	TogglePendingActivities: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "toggle pending activities"),
+	),
+	TogglePauseActivity: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "pause/unpause activity"),
+	),
+	ResetActivity: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "reset activity attempts"),
+	),
+	EditActivityOptions: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "edit activity retry policy and timeouts"),
+	),
+	FocusParent: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "focus parent workflow"),
+	),
+	FocusPreviousRun: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "focus previous run"),
+	),
+	FocusNextRun: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "focus next run"),
+	),
+	FocusFirstRun: key.NewBinding(
+		key.WithKeys("{"),
+		key.WithHelp("{", "focus first run"),
+	),
+	ToggleFollow: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "toggle follow"),
+	),
+	ToggleDetails: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "toggle workflow details"),
+	),
+	SearchHistory: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search history"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PreviousMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
+	ToggleSearchFilter: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "only show matches"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "toggle help"),
+	),
+}
+
+func (k FocusedKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.FocusChildWorkflow, k.NextPayload, k.SearchHistory, k.ToggleDetails, k.Help, k.Back, k.Exit}
+}
+
+func (k FocusedKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.FocusChildWorkflow, k.FocusParent, k.FocusPreviousRun, k.FocusNextRun, k.FocusFirstRun, k.Back, k.Exit},
+		{k.NextPayload, k.YankWorkflowId, k.YankRunId, k.YankRowJson, k.YankPayload, k.SavePayload, k.OpenPayload, k.OpenHistory, k.ExportHistory},
+		{k.ToggleRawEvents, k.ToggleTimeline, k.ToggleChildTree, k.TogglePendingActivities, k.ToggleDetails, k.ToggleFollow, k.Help},
+		{k.TogglePauseActivity, k.ResetActivity, k.EditActivityOptions, k.SearchHistory, k.NextMatch, k.PreviousMatch, k.ToggleSearchFilter},
+	}
+}
+
+// Keys that act on the selected history row, the views without history rows ignore them
+func (k FocusedKeyMap) isHistoryRowKey(msg tea.KeyMsg) bool {
+	return key.Matches(msg, k.FocusChildWorkflow, k.NextPayload, k.YankRowJson, k.YankPayload, k.SavePayload, k.OpenPayload, k.SearchHistory, k.NextMatch, k.PreviousMatch, k.ToggleSearchFilter)
 }
 
 type compactHistoryStackItem struct {
-	workflowId          string
-	runId               string
-	compactHistory      compactedHistory
+	workflowId     string
+	runId          string
+	history        []*history.HistoryEvent
+	compactHistory *compactedHistory
+	// Every history event in chronological order, built the first time the raw view is opened
+	rawHistory          []*compactHistoryListItem
 	workflowDescription *workflowservice.DescribeWorkflowExecutionResponse
+	// nil when the workflow task is healthy
+	workflowTaskProblem *workflowTaskProblem
+	// Loaded the first time the tree view is opened
+	childWorkflowTree *childWorkflowTreeNode
+	// Empty once every page of the history has been loaded
+	historyNextPageToken []byte
+	// Token of the last loaded page, following starts from it instead of the first event
+	lastHistoryPageToken []byte
+	// A page failed to load, the history stops at the last loaded page
+	historyLoadFailed bool
+	// Set when the item is pushed, pages loaded for another item are ignored
+	historyLoadSession int
+}
+
+func (s compactHistoryStackItem) isHistoryLoading() bool {
+	return len(s.historyNextPageToken) > 0
+}
+
+// Features that need every event are disabled while the history is partial
+func (s compactHistoryStackItem) isHistoryPartial() bool {
+	return s.isHistoryLoading() || s.historyLoadFailed
+}
+
+const PARTIAL_HISTORY_MESSAGE = "Only available once the full history is loaded"
+
+type focusedViewMode string
+
+const (
+	COMPACT_VIEW            focusedViewMode = "COMPACT_VIEW"
+	RAW_EVENTS_VIEW         focusedViewMode = "RAW_EVENTS_VIEW"
+	TIMELINE_VIEW           focusedViewMode = "TIMELINE_VIEW"
+	TREE_VIEW               focusedViewMode = "TREE_VIEW"
+	PENDING_ACTIVITIES_VIEW focusedViewMode = "PENDING_ACTIVITIES_VIEW"
+)
+
+var focusedViewModeLabels = map[focusedViewMode]string{
+	RAW_EVENTS_VIEW:         " [raw events]",
+	TIMELINE_VIEW:           " [timeline]",
+	TREE_VIEW:               " [child workflows]",
+	PENDING_ACTIVITIES_VIEW: " [pending activities]",
 }
 
 type focusedModeState struct {
-	cursor                int
-	keys                  FocusedKeyMap
-	compactedHistoryStack []compactHistoryStackItem
+	cursor int
+	// Index of the selected payload box in the detail panel
+	payloadCursor int
+	viewMode      focusedViewMode
+	following     bool
+	followSession int
+	// Rows that changed with the last followed events, highlighted until FOLLOW_HIGHLIGHT_DURATION passed
+	changedEventIds map[int64]bool
+	changedAt       time.Time
+	historySearch   historySearchState
+	// The workflow details panel stays open across workflows
+	showDetails                  bool
+	pendingActivitiesSession     int
+	historyLoadSession           int
+	pendingActivitiesRefreshedAt time.Time
+	keys                         FocusedKeyMap
+	compactedHistoryStack        []compactHistoryStackItem
 }
 
 func (m *focusedModeState) getCurrentHistoryStackItem() compactHistoryStackItem {
 	return m.compactedHistoryStack[len(m.compactedHistoryStack)-1]
 }
 
+// Switches to the view mode, or back to the compact view if it is already active
+func (m *focusedModeState) toggleViewMode(viewMode focusedViewMode) {
+	if m.viewMode == viewMode {
+		m.viewMode = COMPACT_VIEW
+	} else {
+		m.viewMode = viewMode
+	}
+	if m.viewMode == RAW_EVENTS_VIEW {
+		currentHistoryStackItem := &m.compactedHistoryStack[len(m.compactedHistoryStack)-1]
+		if currentHistoryStackItem.rawHistory == nil {
+			currentHistoryStackItem.rawHistory = createRawHistory(currentHistoryStackItem.history)
+		}
+	}
+	m.resetCursors()
+}
+
+func (m *focusedModeState) resetCursors() {
+	m.cursor = 0
+	m.payloadCursor = 0
+}
+
+func (m *model) focusRunCmd(runName string, runId string) tea.Cmd {
+	currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+	currentRunId := currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId()
+	if runId == "" || runId == currentRunId {
+		return statusMessageCmd(fmt.Sprintf("Workflow has no %s run", runName))
+	}
+	return m.setFocusedWorkflowCmd(currentHistoryStackItem.workflowId, runId)
+}
+
+// Returns an empty item when no row is selected, e.g. when the search filter hides every row
+func (m *focusedModeState) getSelectedCompactHistoryItem() *compactHistoryListItem {
+	compactHistorySlice := m.getCurrentCompactHistorySlice()
+	if m.cursor < 0 || m.cursor >= len(compactHistorySlice) {
+		return &compactHistoryListItem{}
+	}
+	return compactHistorySlice[m.cursor]
+}
+
+func (m *focusedModeState) getSelectedPayload() (eventContent, bool) {
+	payloads := m.getSelectedCompactHistoryItem().eventsContent
+	if m.payloadCursor >= len(payloads) {
+		return eventContent{}, false
+	}
+	return payloads[m.payloadCursor], true
+}
+
 func (m *model) UpdateFocusedModeState(msg tea.Msg) (tea.Model, tea.Cmd) {
-	compactedHistory := m.focusedWorkflowState.getCurrentHistoryStackItem().compactHistory
+	compactHistorySlice := m.focusedWorkflowState.getCurrentCompactHistorySlice()
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.focusedWorkflowState.historySearch.input.Focused() {
+			return m, m.updateHistorySearchInput(msg)
+		}
+		if m.focusedWorkflowState.viewMode == TREE_VIEW {
+			if handled, cmd := m.updateChildWorkflowTree(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.focusedWorkflowState.viewMode == PENDING_ACTIVITIES_VIEW {
+			if handled, cmd := m.updatePendingActivities(msg); handled {
+				return m, cmd
+			}
+		}
+		if handled, cmd := m.updateHistorySearch(msg); handled {
+			return m, cmd
+		}
 		switch {
 		case key.Matches(msg, m.focusedWorkflowState.keys.FocusChildWorkflow):
-			currentHistorySlice := m.focusedWorkflowState.getCurrentCompactHistorySlice()
-			if len(currentHistorySlice) < 2 {
-				return m, nil
-			}
-			currentHistoryItem := currentHistorySlice[m.focusedWorkflowState.cursor]
-			// The second event in the compacted history is the child workflow started event (it always comes after the initiated event)
-			secondHistoryEvent := currentHistoryItem.events[1]
-			if secondHistoryEvent.EventType == temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED {
-				executionAttributes := secondHistoryEvent.GetChildWorkflowExecutionStartedEventAttributes()
-				return m, m.setFocusedWorkflowCmd(executionAttributes.WorkflowExecution.GetWorkflowId(), executionAttributes.WorkflowExecution.GetRunId())
+			// Partial histories may be missing the initiated event, so look for the started event in the whole item.
+			// The selected item is empty when the history is empty or the cursor is out of range
+			for _, historyEvent := range m.focusedWorkflowState.getSelectedCompactHistoryItem().events {
+				if historyEvent.GetEventType() == temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED {
+					execution := historyEvent.GetChildWorkflowExecutionStartedEventAttributes().GetWorkflowExecution()
+					return m, m.setFocusedWorkflowCmd(execution.GetWorkflowId(), execution.GetRunId())
+				}
 			}
 		case key.Matches(msg, m.focusedWorkflowState.keys.Up):
 			if m.focusedWorkflowState.cursor > 0 {
 				m.focusedWorkflowState.cursor--
+				m.focusedWorkflowState.payloadCursor = 0
 			}
 		case key.Matches(msg, m.focusedWorkflowState.keys.Down):
-			if m.focusedWorkflowState.cursor < len(compactedHistory)-1 {
+			if m.focusedWorkflowState.cursor < len(compactHistorySlice)-1 {
 				m.focusedWorkflowState.cursor++
+				m.focusedWorkflowState.payloadCursor = 0
+			}
+		case key.Matches(msg, m.focusedWorkflowState.keys.NextPayload):
+			payloads := m.focusedWorkflowState.getSelectedCompactHistoryItem().eventsContent
+			if len(payloads) > 0 {
+				m.focusedWorkflowState.payloadCursor = (m.focusedWorkflowState.payloadCursor + 1) % len(payloads)
 			}
+		case key.Matches(msg, m.focusedWorkflowState.keys.YankWorkflowId):
+			return m, yankCmd("workflow id", m.focusedWorkflowState.getCurrentHistoryStackItem().workflowId)
+		case key.Matches(msg, m.focusedWorkflowState.keys.YankRunId):
+			runId := m.focusedWorkflowState.getCurrentHistoryStackItem().workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId()
+			return m, yankCmd("run id", runId)
+		case key.Matches(msg, m.focusedWorkflowState.keys.YankRowJson):
+			rowJson, err := protoToPrettyJSON(&history.History{Events: m.focusedWorkflowState.getSelectedCompactHistoryItem().events})
+			if err != nil {
+				return m, statusMessageCmd(fmt.Sprintf("Failed to convert row to json: %v", err))
+			}
+			return m, yankCmd("row json", rowJson)
+		case key.Matches(msg, m.focusedWorkflowState.keys.YankPayload):
+			payload, ok := m.focusedWorkflowState.getSelectedPayload()
+			if !ok {
+				return m, statusMessageCmd("No payload selected")
+			}
+			return m, yankCmd(strings.ToLower(payload.eventType), payload.eventData)
+		case key.Matches(msg, m.focusedWorkflowState.keys.SavePayload):
+			payload, ok := m.focusedWorkflowState.getSelectedPayload()
+			if !ok {
+				return m, statusMessageCmd("No payload selected")
+			}
+			selectedItem := m.focusedWorkflowState.getSelectedCompactHistoryItem()
+			if len(selectedItem.events) == 0 {
+				return m, statusMessageCmd("No event selected")
+			}
+			fileName := fmt.Sprintf("%s-%d-%s.json", m.focusedWorkflowState.getCurrentHistoryStackItem().workflowId, selectedItem.events[0].GetEventId(), strings.ToLower(payload.eventType))
+			return m, saveToFileCmd(fileName, payload.eventData)
+		case key.Matches(msg, m.focusedWorkflowState.keys.OpenPayload):
+			payload, ok := m.focusedWorkflowState.getSelectedPayload()
+			if !ok {
+				return m, statusMessageCmd("No payload selected")
+			}
+			return m, openInEditorCmd(m.focusedWorkflowState.getCurrentHistoryStackItem().workflowId+"-"+payload.eventType, payload.eventData)
+		case key.Matches(msg, m.focusedWorkflowState.keys.OpenHistory):
+			currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+			if currentHistoryStackItem.isHistoryPartial() {
+				return m, statusMessageCmd(PARTIAL_HISTORY_MESSAGE)
+			}
+			historyJson, err := historyToJSON(currentHistoryStackItem.history)
+			if err != nil {
+				return m, statusMessageCmd(fmt.Sprintf("Failed to convert history to json: %v", err))
+			}
+			return m, openInEditorCmd(currentHistoryStackItem.workflowId+"-history", historyJson)
+		case key.Matches(msg, m.focusedWorkflowState.keys.ExportHistory):
+			currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+			// A partial history can not be replayed
+			if currentHistoryStackItem.isHistoryPartial() {
+				return m, statusMessageCmd(PARTIAL_HISTORY_MESSAGE)
+			}
+			historyJson, err := historyToJSON(currentHistoryStackItem.history)
+			if err != nil {
+				return m, statusMessageCmd(fmt.Sprintf("Failed to convert history to json: %v", err))
+			}
+			return m, saveToFileCmd(getHistoryExportFileName(currentHistoryStackItem.workflowId, currentHistoryStackItem.runId), historyJson)
+		case key.Matches(msg, m.focusedWorkflowState.keys.ToggleRawEvents):
+			m.focusedWorkflowState.toggleViewMode(RAW_EVENTS_VIEW)
+		case key.Matches(msg, m.focusedWorkflowState.keys.ToggleTimeline):
+			m.focusedWorkflowState.toggleViewMode(TIMELINE_VIEW)
+		case key.Matches(msg, m.focusedWorkflowState.keys.ToggleChildTree):
+			m.focusedWorkflowState.toggleViewMode(TREE_VIEW)
+			currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+			if m.focusedWorkflowState.viewMode == TREE_VIEW && currentHistoryStackItem.childWorkflowTree == nil {
+				return m, m.loadChildWorkflowTreeCmd(currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo())
+			}
+		case key.Matches(msg, m.focusedWorkflowState.keys.TogglePendingActivities):
+			m.focusedWorkflowState.toggleViewMode(PENDING_ACTIVITIES_VIEW)
+			if m.focusedWorkflowState.viewMode == PENDING_ACTIVITIES_VIEW {
+				return m, m.startPendingActivitiesTicksCmd()
+			}
+		case key.Matches(msg, m.focusedWorkflowState.keys.FocusParent):
+			parentExecution := m.focusedWorkflowState.getCurrentHistoryStackItem().workflowDescription.GetWorkflowExecutionInfo().GetParentExecution()
+			if parentExecution == nil {
+				return m, statusMessageCmd("Workflow has no parent")
+			}
+			return m, m.setFocusedWorkflowCmd(parentExecution.GetWorkflowId(), parentExecution.GetRunId())
+		case key.Matches(msg, m.focusedWorkflowState.keys.FocusPreviousRun):
+			return m, m.focusRunCmd("previous", getPreviousRunId(m.focusedWorkflowState.getCurrentHistoryStackItem().history))
+		case key.Matches(msg, m.focusedWorkflowState.keys.FocusNextRun):
+			// The next run is only recorded in the last event
+			if m.focusedWorkflowState.getCurrentHistoryStackItem().isHistoryPartial() {
+				return m, statusMessageCmd(PARTIAL_HISTORY_MESSAGE)
+			}
+			return m, m.focusRunCmd("next", getNextRunId(m.focusedWorkflowState.getCurrentHistoryStackItem().history))
+		case key.Matches(msg, m.focusedWorkflowState.keys.FocusFirstRun):
+			return m, m.focusRunCmd("first", getFirstRunId(m.focusedWorkflowState.getCurrentHistoryStackItem().history))
+		case key.Matches(msg, m.focusedWorkflowState.keys.ToggleDetails):
+			m.focusedWorkflowState.showDetails = !m.focusedWorkflowState.showDetails
+		case key.Matches(msg, m.focusedWorkflowState.keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+		case key.Matches(msg, m.focusedWorkflowState.keys.ToggleFollow):
+			if m.focusedWorkflowState.following {
+				m.focusedWorkflowState.following = false
+				return m, nil
+			}
+			if m.focusedWorkflowState.getCurrentHistoryStackItem().workflowDescription.GetWorkflowExecutionInfo().GetStatus() != temporalEnums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+				return m, statusMessageCmd("Only running workflows can be followed")
+			}
+			if m.focusedWorkflowState.getCurrentHistoryStackItem().isHistoryPartial() {
+				return m, statusMessageCmd(PARTIAL_HISTORY_MESSAGE)
+			}
+			return m, m.startFollowingCmd()
 		case key.Matches(msg, m.focusedWorkflowState.keys.Back):
+			// There is no list to go back to in offline mode
+			if m.offline && len(m.focusedWorkflowState.compactedHistoryStack) == 1 {
+				return m, tea.Quit
+			}
+			m.focusedWorkflowState.following = false
+			m.focusedWorkflowState.historySearch = newHistorySearchState()
 			m.focusedWorkflowState.compactedHistoryStack = m.focusedWorkflowState.compactedHistoryStack[:len(m.focusedWorkflowState.compactedHistoryStack)-1]
-			m.focusedWorkflowState.cursor = 0
+			m.focusedWorkflowState.viewMode = COMPACT_VIEW
+			m.focusedWorkflowState.resetCursors()
+			return m, m.resumeRelativeTimeTickCmd()
 		case key.Matches(msg, m.focusedWorkflowState.keys.Exit):
 			return m, tea.Quit
 		}
@@ -100,201 +486,22 @@ func (m *model) UpdateFocusedModeState(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-type eventContent struct {
-	eventType string
-	eventData string
-}
-
-type compactHistoryListItem struct {
-	events        []*history.HistoryEvent
-	eventsContent []eventContent
-	icon          string
-	actionType    string
-	rowContent    string
-}
-
-type compactedHistory map[int64]*compactHistoryListItem
-
-var activityNameStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF00FF")).Bold(true)
-var jsonOutputStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF00FF")).Bold(false)
-
-func convertDataToPrettyJSON(data []byte) string {
-	var prettyJSON map[string]interface{}
-	json.Unmarshal(data, &prettyJSON)
-	prettyJSONBytes, _ := json.MarshalIndent(prettyJSON, "", "  ")
-	return string(prettyJSONBytes)
-}
-
-func createCompactHistory(historyList []*history.HistoryEvent, pendingActivities []*workflow.PendingActivityInfo) compactedHistory {
-	compactedHistory := make(compactedHistory)
+// Creates one row per history event with all of its attributes rendered as json
+func createRawHistory(historyList []*history.HistoryEvent) []*compactHistoryListItem {
+	rawHistory := make([]*compactHistoryListItem, 0, len(historyList))
 	for _, historyEvent := range historyList {
-
-		eventType := historyEvent.GetEventType()
-		switch historyEvent.GetEventType() {
-		// Activity events
-		// Activity events are special because they have multiple events that are related to each other
-		// Activity events are grouped by the scheduled event id
-		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
-			eventId := historyEvent.GetEventId()
-			attributes := historyEvent.GetActivityTaskScheduledEventAttributes()
-			compactedHistory[eventId] = &compactHistoryListItem{events: make([]*history.HistoryEvent, 0)}
-			compactedHistory[eventId].actionType = "Activity"
-			compactedHistory[eventId].icon = "📅"
-
-			compactedHistory[eventId].rowContent = historyEvent.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName()
-			for _, pendingActivity := range pendingActivities {
-				if pendingActivity.GetActivityId() == attributes.GetActivityId() {
-					errorCause := pendingActivity.GetLastFailure().GetCause().GetMessage()
-					compactedHistory[eventId].eventsContent = append(compactedHistory[eventId].eventsContent, eventContent{eventType: "Last Error", eventData: errorCause})
-					compactedHistory[eventId].rowContent += " 🔄" + strconv.Itoa(int(pendingActivity.GetAttempt()))
-					break
-				}
-
-			}
-			if attributes.GetInput().GetPayloads() != nil {
-				prettyJSONString := convertDataToPrettyJSON(attributes.GetInput().GetPayloads()[0].GetData())
-				compactedHistory[eventId].eventsContent = append(compactedHistory[eventId].eventsContent, eventContent{eventType: "Input", eventData: prettyJSONString})
-			}
-			compactedHistory[eventId].events = append(compactedHistory[eventId].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_STARTED:
-			activityTaskStartedEventAttributes := historyEvent.GetActivityTaskStartedEventAttributes()
-			eventId := activityTaskStartedEventAttributes.GetScheduledEventId()
-			compactedHistory[eventId].icon = "🏃"
-			compactedHistory[eventId].events = append(compactedHistory[activityTaskStartedEventAttributes.GetScheduledEventId()].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
-			activityTaskCompletedEventAttributes := historyEvent.GetActivityTaskCompletedEventAttributes()
-			eventId := activityTaskCompletedEventAttributes.GetScheduledEventId()
-			event := compactedHistory[eventId]
-			prettyJsonString := convertDataToPrettyJSON(activityTaskCompletedEventAttributes.GetResult().GetPayloads()[0].GetData())
-			event.icon = "✅"
-			event.events = append(event.events, historyEvent)
-			compactedHistory[eventId].eventsContent = append(compactedHistory[eventId].eventsContent, eventContent{eventType: "Output", eventData: prettyJsonString})
-		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_FAILED:
-			activityTaskFailedEventAttributes := historyEvent.GetActivityTaskFailedEventAttributes()
-			eventId := activityTaskFailedEventAttributes.GetScheduledEventId()
-			compactedHistory[eventId].icon = "❌"
-			compactedHistory[activityTaskFailedEventAttributes.GetScheduledEventId()].events = append(compactedHistory[activityTaskFailedEventAttributes.GetScheduledEventId()].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
-			activityTaskTimedOutEventAttributes := historyEvent.GetActivityTaskTimedOutEventAttributes()
-			eventId := activityTaskTimedOutEventAttributes.GetScheduledEventId()
-			compactedHistory[eventId].icon = "⏰"
-			compactedHistory[activityTaskTimedOutEventAttributes.GetScheduledEventId()].events = append(compactedHistory[activityTaskTimedOutEventAttributes.GetScheduledEventId()].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_CANCEL_REQUESTED:
-			activityTaskCancelRequestedEventAttributes := historyEvent.GetActivityTaskCancelRequestedEventAttributes()
-			eventId := activityTaskCancelRequestedEventAttributes.GetScheduledEventId()
-			compactedHistory[eventId].icon = "🚫"
-			compactedHistory[activityTaskCancelRequestedEventAttributes.GetScheduledEventId()].events = append(compactedHistory[activityTaskCancelRequestedEventAttributes.GetScheduledEventId()].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
-			activityTaskCanceledEventAttributes := historyEvent.GetActivityTaskCanceledEventAttributes()
-			eventId := activityTaskCanceledEventAttributes.GetScheduledEventId()
-			compactedHistory[eventId].icon = "🚫"
-			compactedHistory[activityTaskCanceledEventAttributes.GetScheduledEventId()].events = append(compactedHistory[activityTaskCanceledEventAttributes.GetScheduledEventId()].events, historyEvent)
-		// Timer events
-		case temporalEnums.EVENT_TYPE_TIMER_STARTED:
-			eventId := historyEvent.GetEventId()
-			// initialize the compacted history list
-			compactedHistory[eventId] = &compactHistoryListItem{events: make([]*history.HistoryEvent, 0)}
-			compactedHistory[eventId].actionType = "Timer"
-			compactedHistory[eventId].icon = "⏰"
-			compactedHistory[eventId].rowContent = historyEvent.GetTimerStartedEventAttributes().GetTimerId()
-			compactedHistory[eventId].events = append(compactedHistory[eventId].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_TIMER_FIRED:
-			timerFiredEventAttributes := historyEvent.GetTimerFiredEventAttributes()
-			eventId := timerFiredEventAttributes.GetStartedEventId()
-			compactedHistory[eventId].icon = "🔥"
-			compactedHistory[eventId].events = append(compactedHistory[timerFiredEventAttributes.GetStartedEventId()].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_TIMER_CANCELED:
-			timerCanceledEventAttributes := historyEvent.GetTimerCanceledEventAttributes()
-			eventId := timerCanceledEventAttributes.GetStartedEventId()
-			compactedHistory[eventId].icon = "🚫"
-			compactedHistory[eventId].events = append(compactedHistory[timerCanceledEventAttributes.GetStartedEventId()].events, historyEvent)
-
-		// Child workflow events
-		case temporalEnums.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
-			eventId := historyEvent.GetEventId()
-			// initialize the compacted history list
-			compactedHistory[eventId] = &compactHistoryListItem{events: make([]*history.HistoryEvent, 0)}
-			inputPayloads := historyEvent.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetInput().GetPayloads()
-			if inputPayloads != nil {
-				prettyJsonString := convertDataToPrettyJSON(historyEvent.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetInput().GetPayloads()[0].GetData())
-				compactedHistory[eventId].eventsContent = append(compactedHistory[eventId].eventsContent, eventContent{eventType: "Input", eventData: prettyJsonString})
-			}
-			compactedHistory[eventId].actionType = "Child Workflow"
-			compactedHistory[eventId].icon = "👶🏃"
-			compactedHistory[eventId].rowContent = historyEvent.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetWorkflowType().GetName()
-			compactedHistory[eventId].events = append(compactedHistory[eventId].events, historyEvent)
-
-		case temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
-			childWorkflowExecutionStartedEventAttributes := historyEvent.GetChildWorkflowExecutionStartedEventAttributes()
-			eventId := childWorkflowExecutionStartedEventAttributes.GetInitiatedEventId()
-			compactedHistory[eventId].icon = "🏃👶"
-			compactedHistory[eventId].events = append(compactedHistory[childWorkflowExecutionStartedEventAttributes.GetInitiatedEventId()].events, historyEvent)
-
-		case temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:
-			childWorkflowExecutionCompletedEventAttributes := historyEvent.GetChildWorkflowExecutionCompletedEventAttributes()
-			eventId := childWorkflowExecutionCompletedEventAttributes.GetInitiatedEventId()
-			inputPayloads := childWorkflowExecutionCompletedEventAttributes.GetResult().GetPayloads()
-			if inputPayloads != nil {
-				prettyJsonString := convertDataToPrettyJSON(childWorkflowExecutionCompletedEventAttributes.GetResult().GetPayloads()[0].GetData())
-				compactedHistory[eventId].eventsContent = append(compactedHistory[eventId].eventsContent, eventContent{eventType: "Output", eventData: prettyJsonString})
-			}
-			compactedHistory[eventId].icon = "✅👶"
-			compactedHistory[eventId].events = append(compactedHistory[childWorkflowExecutionCompletedEventAttributes.GetInitiatedEventId()].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
-			childWorkflowExecutionFailedEventAttributes := historyEvent.GetChildWorkflowExecutionFailedEventAttributes()
-			eventId := childWorkflowExecutionFailedEventAttributes.GetInitiatedEventId()
-			compactedHistory[eventId].icon = "❌👶"
-		// General workflow events
-		case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
-			eventId := historyEvent.GetEventId()
-			executionStartedEventAttributes := historyEvent.GetWorkflowExecutionStartedEventAttributes()
-
-			// initialize the compacted history list
-			compactedHistory[eventId] = &compactHistoryListItem{events: make([]*history.HistoryEvent, 0)}
-			inputPayloads := executionStartedEventAttributes.GetInput().GetPayloads()
-
-			if inputPayloads != nil {
-				prettyJsonString := convertDataToPrettyJSON(executionStartedEventAttributes.GetInput().GetPayloads()[0].GetData())
-				compactedHistory[eventId].eventsContent = append(compactedHistory[eventId].eventsContent, eventContent{eventType: "Input", eventData: prettyJsonString})
-			}
-			compactedHistory[eventId].actionType = eventType.String()
-			compactedHistory[eventId].icon = "🚀"
-			compactedHistory[eventId].rowContent = "Workflow started"
-			compactedHistory[eventId].events = append(compactedHistory[eventId].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
-			eventId := historyEvent.GetEventId()
-			compactedHistory[eventId] = &compactHistoryListItem{events: make([]*history.HistoryEvent, 0)}
-			executionCompletedEventAttributes := historyEvent.GetWorkflowExecutionCompletedEventAttributes()
-			outputPayloads := executionCompletedEventAttributes.GetResult().GetPayloads()
-			if outputPayloads != nil {
-				prettyJsonString := convertDataToPrettyJSON(executionCompletedEventAttributes.GetResult().GetPayloads()[0].GetData())
-				compactedHistory[eventId].eventsContent = append(compactedHistory[eventId].eventsContent, eventContent{eventType: "Output", eventData: prettyJsonString})
-			}
-			compactedHistory[eventId].actionType = eventType.String()
-			compactedHistory[eventId].icon = "✅"
-			compactedHistory[eventId].events = append(compactedHistory[eventId].events, historyEvent)
-		case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
-			eventId := historyEvent.GetEventId()
-			compactedHistory[eventId] = &compactHistoryListItem{events: make([]*history.HistoryEvent, 0)}
-			signalName := historyEvent.GetWorkflowExecutionSignaledEventAttributes().GetSignalName()
-			compactedHistory[eventId].actionType = eventType.String()
-			compactedHistory[eventId].icon = "🛜"
-			compactedHistory[eventId].rowContent = signalName
-			compactedHistory[eventId].events = append(compactedHistory[eventId].events, historyEvent)
-
-		default:
-			eventId := historyEvent.GetEventId()
-			eventType := historyEvent.GetEventType()
-			// initialize the compacted history list
-			if compactedHistory[eventId] == nil && !strings.Contains(eventType.String(), "WorkflowTask") {
-				compactedHistory[eventId] = &compactHistoryListItem{events: make([]*history.HistoryEvent, 0)}
-				compactedHistory[eventId].actionType = eventType.String()
-				compactedHistory[eventId].events = append(compactedHistory[eventId].events, historyEvent)
-			}
-
+		eventJson, err := protoToPrettyJSON(historyEvent)
+		if err != nil {
+			eventJson = historyEvent.String()
 		}
+		rawHistory = append(rawHistory, &compactHistoryListItem{
+			events:        []*history.HistoryEvent{historyEvent},
+			eventsContent: []eventContent{{eventType: "Event", eventData: eventJson}},
+			actionType:    historyEvent.GetEventType().String(),
+			rowContent:    historyEvent.GetEventTime().AsTime().In(time.Local).Format(time.RFC3339),
+		})
 	}
-	return compactedHistory
+	return rawHistory
 }
 
 var leftBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
@@ -303,6 +510,7 @@ var bottomBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
 var historyListBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
 var historyDetailBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
 var topBarStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+var selectedPayloadBorderColor = lipgloss.Color("#00ff00")
 
 var (
 	topBarHeight = 3
@@ -366,37 +574,88 @@ func (m *model) createEventDetailsRows(compactHistoryListItem compactHistoryList
 	if len(focusedHistoryEvents) != 0 {
 		eventBlockHeight = height / len(focusedHistoryEvents)
 	}
-	for _, historyEvent := range focusedHistoryEvents {
+	for i, historyEvent := range focusedHistoryEvents {
 		truncatedHistoryEvent := truncateTextBlock(historyEvent.eventData, eventBlockHeight, width)
-		focusedHistoryEventContent += getModuleBorderStyle(width-2, historyEvent.eventType).Render(truncatedHistoryEvent) + "\n"
+		moduleStyle := getModuleBorderStyle(width-2, historyEvent.eventType)
+		if i == m.focusedWorkflowState.payloadCursor {
+			moduleStyle = moduleStyle.BorderForeground(selectedPayloadBorderColor)
+		}
+		focusedHistoryEventContent += moduleStyle.Render(truncatedHistoryEvent) + "\n"
 	}
 	return lipgloss.NewStyle().Width(width).Height(height).Render(focusedHistoryEventContent)
 }
 
-func (m *focusedModeState) getCurrentCompactHistorySlice() []*compactHistoryListItem {
-	// Convert compactHistory into a slice
-	compactHistorySlice := make([]*compactHistoryListItem, 0)
-
-	for _, compactHistoryItem := range m.getCurrentHistoryStackItem().compactHistory {
-		compactHistorySlice = append(compactHistorySlice, compactHistoryItem)
+func (m *focusedModeState) getCurrentHistorySearchResults() *historySearchResults {
+	currentHistoryStackItem := m.getCurrentHistoryStackItem()
+	// The raw history is only appended to together with the compacted history, so they share its version
+	version := currentHistoryStackItem.compactHistory.version
+	if m.viewMode == RAW_EVENTS_VIEW {
+		return m.historySearch.getResults(currentHistoryStackItem.rawHistory, version)
 	}
+	return m.historySearch.getResults(currentHistoryStackItem.compactHistory.getSortedItems(), version)
+}
 
-	// Pending events
-	sort.Slice(compactHistorySlice, func(i, j int) bool {
-		// Sort by the first eventid
-		return compactHistorySlice[i].events[0].GetEventId() > compactHistorySlice[j].events[0].GetEventId()
-	})
-	return compactHistorySlice
+func (m *focusedModeState) getCurrentCompactHistorySlice() []*compactHistoryListItem {
+	return m.getCurrentHistorySearchResults().filteredItems
 }
 
 // Each border is .5 characters wide, so we subtract 2 from the width and height
 func (m model) focusedModeView() string {
+	topSection := m.renderFocusedModeTopSection()
+	footer := m.renderFocusedModeFooter()
+	bodyHeight := m.viewport.Height - lipgloss.Height(topSection) - lipgloss.Height(footer)
+
+	var body string
+	switch m.focusedWorkflowState.viewMode {
+	case TIMELINE_VIEW:
+		body = m.renderTimeline(m.viewport.Width-1, bodyHeight-4)
+	case TREE_VIEW:
+		body = m.renderChildWorkflowTree(m.viewport.Width-1, bodyHeight-4)
+	case PENDING_ACTIVITIES_VIEW:
+		body = m.renderPendingActivities(m.viewport.Width-1, bodyHeight-4)
+	default:
+		body = m.renderHistoryPanels(bodyHeight - 4)
+	}
+	return lipgloss.JoinVertical(lipgloss.Top, topSection, body, footer)
+}
 
-	boxWidth := m.viewport.Width / 2
-	bottomAreaHeight := m.viewport.Height - topBarHeight - 5
-	historyListBoxStyleWithDem := historyListBoxStyle.Height(bottomAreaHeight).Width(boxWidth - 2)
-
+func (m model) renderFocusedModeTopSection() string {
 	currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+	statusIcon := statusToStyleMap[currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetStatus().String()].icon
+	childIcon := ""
+	if currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetParentExecution() != nil {
+		childIcon = "👶"
+	}
+	viewModeLabel := focusedViewModeLabels[m.focusedWorkflowState.viewMode]
+	if m.focusedWorkflowState.following {
+		viewModeLabel += " [following]"
+	}
+	if m.offline {
+		viewModeLabel += " [offline]"
+	}
+	if currentHistoryStackItem.isHistoryLoading() {
+		viewModeLabel += fmt.Sprintf(" [loading history, %d events]", len(currentHistoryStackItem.history))
+	} else if currentHistoryStackItem.historyLoadFailed {
+		viewModeLabel += fmt.Sprintf(" [partial history, %d events]", len(currentHistoryStackItem.history))
+	}
+	topBarPrefix := childIcon + " " + statusIcon + " Workflow ID: "
+	breadcrumbWidth := m.viewport.Width - 3 - lipgloss.Width(topBarPrefix) - lipgloss.Width(viewModeLabel)
+	topBarContent := topBarStyle.Height(topBarHeight - 2).Width(m.viewport.Width - 3).Render(topBarPrefix + m.focusedWorkflowState.renderBreadcrumb(breadcrumbWidth) + viewModeLabel)
+
+	if currentHistoryStackItem.workflowTaskProblem != nil {
+		topBarContent = lipgloss.JoinVertical(lipgloss.Top, topBarContent, currentHistoryStackItem.workflowTaskProblem.renderBanner(m.viewport.Width-3))
+	}
+	if m.focusedWorkflowState.showDetails {
+		topBarContent = lipgloss.JoinVertical(lipgloss.Top, topBarContent, m.renderWorkflowDetails(m.viewport.Width-1, m.viewport.Height/2))
+	}
+	return topBarContent
+}
+
+// Renders the event details on the left and the history list on the right
+func (m model) renderHistoryPanels(height int) string {
+	boxWidth := m.viewport.Width / 2
+	historyListBoxStyleWithDem := historyListBoxStyle.Height(height).Width(boxWidth - 2)
+	compactHistorySlice := m.focusedWorkflowState.getCurrentCompactHistorySlice()
 
 	historyEventTableStyle := table.New().
 		Width(historyListBoxStyleWithDem.GetWidth()).
@@ -405,6 +664,10 @@ func (m model) focusedModeView() string {
 			switch {
 			case row == m.focusedWorkflowState.cursor:
 				return SelectedRowStyle
+			case row >= 0 && row < len(compactHistorySlice) && m.focusedWorkflowState.isRowChanged(compactHistorySlice[row]):
+				return followChangedRowStyle
+			case row >= 0 && row < len(compactHistorySlice) && m.focusedWorkflowState.historySearch.query != "" && m.focusedWorkflowState.historySearch.matches(compactHistorySlice[row]):
+				return historySearchMatchStyle
 			case row%2 == 0:
 				return EvenRowStyle
 			default:
@@ -412,22 +675,26 @@ func (m model) focusedModeView() string {
 			}
 		})
 
-	// Convert compactHistory into a slice
-	compactHistorySlice := m.focusedWorkflowState.getCurrentCompactHistorySlice()
 	for _, compactHistoryItem := range compactHistorySlice {
 		firstEvent := compactHistoryItem.events[0]
 		historyEventTableStyle.Row(compactHistoryItem.icon, strconv.FormatInt(firstEvent.GetEventId(), 10), compactHistoryItem.actionType, compactHistoryItem.rowContent)
 	}
 
-	focusedHistoryEvents := compactHistorySlice[m.focusedWorkflowState.cursor]
-	focusedHistoryEventContent := m.createEventDetailsRows(*focusedHistoryEvents, boxWidth-2, bottomAreaHeight)
-	statusIcon := statusToStyleMap[currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetStatus().String()].icon
-	childIcon := ""
-	if currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetParentExecution() != nil {
-		childIcon = "👶"
-	}
-	topBarContent := topBarStyle.Height(topBarHeight - 2).Width(m.viewport.Width - 3).Render(childIcon + " " + statusIcon + " Workflow ID: " + currentHistoryStackItem.workflowId)
+	focusedHistoryEventContent := m.createEventDetailsRows(*m.focusedWorkflowState.getSelectedCompactHistoryItem(), boxWidth-2, height)
 
-	return lipgloss.JoinVertical(lipgloss.Top, topBarContent, lipgloss.JoinHorizontal(lipgloss.Top, focusedHistoryEventContent, historyListBoxStyleWithDem.Render(historyEventTableStyle.Render())))
+	return lipgloss.JoinHorizontal(lipgloss.Top, focusedHistoryEventContent, historyListBoxStyleWithDem.Render(historyEventTableStyle.Render()))
+}
 
+func (m model) renderFocusedModeFooter() string {
+	if confirmationFooter, ok := m.renderConfirmationFlowFooter(); ok {
+		return confirmationFooter
+	}
+	if m.statusMessage != "" {
+		return m.statusMessage
+	}
+	helpView := m.help.View(m.focusedWorkflowState.keys)
+	if searchStatus := m.renderHistorySearchStatus(); searchStatus != "" {
+		return lipgloss.JoinVertical(lipgloss.Top, searchStatus, helpView)
+	}
+	return helpView
 }
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"go.temporal.io/api/common/v1"
+	temporalEnums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ========================================
+// Follow Mode
+// ========================================
+
+const (
+	// The server returns an empty page when no event arrives before the long poll times out
+	FOLLOW_LONG_POLL_TIMEOUT = time.Second * 60
+	// How long rows that just changed stay highlighted
+	FOLLOW_HIGHLIGHT_DURATION = time.Second * 3
+)
+
+var followChangedRowStyle = lipgloss.NewStyle().Padding(0, 0).Background(lipgloss.Color("#553300"))
+
+type followWorkflowMsg struct {
+	// Messages of a previous follow session are ignored
+	followSession int
+	workflowId    string
+	runId         string
+	newEvents     []*history.HistoryEvent
+	nextPageToken []byte
+	description   *workflowservice.DescribeWorkflowExecutionResponse
+	err           error
+}
+
+type followHighlightExpiredMsg struct {
+	changedAt time.Time
+}
+
+// Long polls the history for events after lastEventId and refreshes the description when new events arrive
+func (m *model) followWorkflowCmd(workflowId string, runId string, nextPageToken []byte, lastEventId int64) tea.Cmd {
+	followSession := m.focusedWorkflowState.followSession
+	return func() tea.Msg {
+		temporalClient, _ := m.getTemporalClient()
+		namespaceInfo := m.getTemporalConfig()
+		ctx, cancel := context.WithTimeout(context.Background(), FOLLOW_LONG_POLL_TIMEOUT)
+		defer cancel()
+		response, err := temporalClient.WorkflowService().GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+			Namespace:              namespaceInfo.TemporalNamespace,
+			Execution:              &common.WorkflowExecution{WorkflowId: workflowId, RunId: runId},
+			NextPageToken:          nextPageToken,
+			WaitNewEvent:           true,
+			HistoryEventFilterType: temporalEnums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT,
+		})
+		if errors.Is(err, context.DeadlineExceeded) {
+			return followWorkflowMsg{followSession: followSession, workflowId: workflowId, runId: runId, nextPageToken: nextPageToken}
+		}
+		if err != nil {
+			return followWorkflowMsg{followSession: followSession, workflowId: workflowId, runId: runId, err: err}
+		}
+		// The first poll starts at the last loaded page, skip the events we already have
+		newEvents := []*history.HistoryEvent{}
+		for _, historyEvent := range response.GetHistory().GetEvents() {
+			if historyEvent.GetEventId() > lastEventId {
+				newEvents = append(newEvents, historyEvent)
+			}
+		}
+		msg := followWorkflowMsg{followSession: followSession, workflowId: workflowId, runId: runId, newEvents: newEvents, nextPageToken: response.GetNextPageToken()}
+		if len(newEvents) > 0 || len(response.GetNextPageToken()) == 0 {
+			description, err := temporalClient.DescribeWorkflowExecution(context.Background(), workflowId, runId)
+			if err != nil {
+				return followWorkflowMsg{followSession: followSession, workflowId: workflowId, runId: runId, err: err}
+			}
+			msg.description = description
+		}
+		return msg
+	}
+}
+
+func (m *model) startFollowingCmd() tea.Cmd {
+	m.focusedWorkflowState.following = true
+	m.focusedWorkflowState.followSession++
+	currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+	lastEventId := int64(0)
+	if len(currentHistoryStackItem.history) > 0 {
+		lastEventId = currentHistoryStackItem.history[len(currentHistoryStackItem.history)-1].GetEventId()
+	}
+	runId := currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId()
+	return m.followWorkflowCmd(currentHistoryStackItem.workflowId, runId, currentHistoryStackItem.lastHistoryPageToken, lastEventId)
+}
+
+func (m model) handleFollowWorkflowMsg(msg followWorkflowMsg) (tea.Model, tea.Cmd) {
+	if !m.focusedWorkflowState.following || msg.followSession != m.focusedWorkflowState.followSession || len(m.focusedWorkflowState.compactedHistoryStack) == 0 {
+		return m, nil
+	}
+	stackIndex := len(m.focusedWorkflowState.compactedHistoryStack) - 1
+	currentHistoryStackItem := &m.focusedWorkflowState.compactedHistoryStack[stackIndex]
+	currentRunId := currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId()
+	// The user focused another workflow while the long poll was running
+	if currentHistoryStackItem.workflowId != msg.workflowId || currentRunId != msg.runId {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.focusedWorkflowState.following = false
+		return m, statusMessageCmd(fmt.Sprintf("Stopped following: %v", msg.err))
+	}
+
+	// Keep the cursor on the same row while new rows are added above it
+	selectedEventId := int64(0)
+	compactHistorySlice := m.focusedWorkflowState.getCurrentCompactHistorySlice()
+	if m.focusedWorkflowState.cursor < len(compactHistorySlice) {
+		selectedEventId = compactHistorySlice[m.focusedWorkflowState.cursor].events[0].GetEventId()
+	}
+
+	eventCountBefore := make(map[int64]int)
+	for eventId, compactHistoryItem := range currentHistoryStackItem.compactHistory.itemsByEventId {
+		eventCountBefore[eventId] = len(compactHistoryItem.events)
+	}
+	currentHistoryStackItem.history = append(currentHistoryStackItem.history, msg.newEvents...)
+	currentHistoryStackItem.compactHistory.addEvents(msg.newEvents)
+	if currentHistoryStackItem.rawHistory != nil {
+		currentHistoryStackItem.rawHistory = append(currentHistoryStackItem.rawHistory, createRawHistory(msg.newEvents)...)
+	}
+	if msg.description != nil {
+		currentHistoryStackItem.workflowDescription = msg.description
+		currentHistoryStackItem.compactHistory.applyPendingActivities(msg.description.GetPendingActivities())
+		currentHistoryStackItem.workflowTaskProblem = getWorkflowTaskProblem(currentHistoryStackItem.history, msg.description)
+	}
+
+	changedAt := time.Now()
+	changedEventIds := make(map[int64]bool)
+	for eventId, compactHistoryItem := range currentHistoryStackItem.compactHistory.itemsByEventId {
+		if count, ok := eventCountBefore[eventId]; !ok || count != len(compactHistoryItem.events) {
+			changedEventIds[eventId] = true
+		}
+	}
+	for _, historyEvent := range msg.newEvents {
+		changedEventIds[historyEvent.GetEventId()] = true
+	}
+	cmds := []tea.Cmd{}
+	if len(msg.newEvents) > 0 {
+		m.focusedWorkflowState.changedEventIds = changedEventIds
+		m.focusedWorkflowState.changedAt = changedAt
+		cmds = append(cmds, tea.Tick(FOLLOW_HIGHLIGHT_DURATION, func(_ time.Time) tea.Msg {
+			return followHighlightExpiredMsg{changedAt: changedAt}
+		}))
+	}
+
+	for i, compactHistoryItem := range m.focusedWorkflowState.getCurrentCompactHistorySlice() {
+		if compactHistoryItem.events[0].GetEventId() == selectedEventId {
+			m.focusedWorkflowState.cursor = i
+			break
+		}
+	}
+
+	// An empty page token means the workflow is closed and no more events will arrive
+	if len(msg.nextPageToken) == 0 {
+		m.focusedWorkflowState.following = false
+		cmds = append(cmds, statusMessageCmd("Workflow closed, stopped following"))
+		return m, tea.Batch(cmds...)
+	}
+	lastEventId := currentHistoryStackItem.history[len(currentHistoryStackItem.history)-1].GetEventId()
+	cmds = append(cmds, m.followWorkflowCmd(msg.workflowId, msg.runId, msg.nextPageToken, lastEventId))
+	return m, tea.Batch(cmds...)
+}
+
+func (m *focusedModeState) isRowChanged(compactHistoryItem *compactHistoryListItem) bool {
+	if time.Since(m.changedAt) > FOLLOW_HIGHLIGHT_DURATION {
+		return false
+	}
+	return m.changedEventIds[compactHistoryItem.events[0].GetEventId()]
+}
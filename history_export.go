@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.temporal.io/api/history/v1"
+)
+
+// ========================================
+// History Export
+// ========================================
+
+// Same json as `temporal workflow show --output json`, it can be loaded by the SDK replayer
+// (worker.WorkflowReplayer.ReplayWorkflowHistoryFromJSONFile) and client.HistoryFromJSON
+func historyToJSON(events []*history.HistoryEvent) (string, error) {
+	return protoToPrettyJSON(&history.History{Events: events})
+}
+
+func getHistoryExportFileName(workflowId string, runId string) string {
+	if runId == "" {
+		return sanitizeFileName(workflowId) + "-history.json"
+	}
+	return sanitizeFileName(workflowId+"-"+runId) + "-history.json"
+}
+
+// kairos-cli export-history [-o file] <workflowId> [runId]
+func exportHistoryCommand(args []string) error {
+	flags := flag.NewFlagSet("export-history", flag.ExitOnError)
+	output := flags.String("o", "", "Output file, defaults to <workflowId>-<runId>-history.json")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kairos-cli export-history [-o file] <workflowId> [runId]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		flags.Usage()
+		return fmt.Errorf("expected a workflow id and an optional run id")
+	}
+	workflowId := flags.Arg(0)
+	runId := flags.Arg(1)
+	m := initialModel()
+	events, err := m.GetWorkflowHistory(context.Background(), workflowId, runId)
+	if err != nil {
+		return fmt.Errorf("failed to fetch history: %w", err)
+	}
+	historyJson, err := historyToJSON(events)
+	if err != nil {
+		return fmt.Errorf("failed to convert history to json: %w", err)
+	}
+	path := *output
+	if path == "" {
+		path = getHistoryExportFileName(workflowId, runId)
+	}
+	if err := os.WriteFile(path, []byte(historyJson), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d events to %s\n", len(events), path)
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.temporal.io/api/common/v1"
+	temporalEnums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ========================================
+// History Pages
+// ========================================
+
+// The first page is shown right away, the rest of the history is loaded in the background
+const HISTORY_PAGE_SIZE = 1000
+
+type historyPageMsg struct {
+	// Pages of a previous load of the same run are ignored
+	session    int
+	workflowId string
+	runId      string
+	events     []*history.HistoryEvent
+	// Token the page was requested with
+	pageToken     []byte
+	nextPageToken []byte
+	err           error
+}
+
+func (m *model) getHistoryPage(workflowId string, runId string, nextPageToken []byte) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+	temporalClient, _ := m.getTemporalClient()
+	namespaceInfo := m.getTemporalConfig()
+	return temporalClient.WorkflowService().GetWorkflowExecutionHistory(context.Background(), &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace:              namespaceInfo.TemporalNamespace,
+		Execution:              &common.WorkflowExecution{WorkflowId: workflowId, RunId: runId},
+		MaximumPageSize:        HISTORY_PAGE_SIZE,
+		NextPageToken:          nextPageToken,
+		HistoryEventFilterType: temporalEnums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT,
+	})
+}
+
+func (m *model) loadHistoryPageCmd(session int, workflowId string, runId string, nextPageToken []byte) tea.Cmd {
+	return func() tea.Msg {
+		response, err := m.getHistoryPage(workflowId, runId, nextPageToken)
+		if err != nil {
+			return historyPageMsg{session: session, workflowId: workflowId, runId: runId, err: err}
+		}
+		return historyPageMsg{session: session, workflowId: workflowId, runId: runId, events: response.GetHistory().GetEvents(), pageToken: nextPageToken, nextPageToken: response.GetNextPageToken()}
+	}
+}
+
+func (m model) handleHistoryPageMsg(msg historyPageMsg) (tea.Model, tea.Cmd) {
+	// The same run can be on the stack more than once, every item loads its own pages
+	stackIndex := -1
+	for i, stackItem := range m.focusedWorkflowState.compactedHistoryStack {
+		if stackItem.historyLoadSession == msg.session {
+			stackIndex = i
+		}
+	}
+	// The workflow was closed before its history finished loading
+	if stackIndex == -1 {
+		return m, nil
+	}
+	stackItem := &m.focusedWorkflowState.compactedHistoryStack[stackIndex]
+	if msg.err != nil {
+		stackItem.historyNextPageToken = nil
+		stackItem.historyLoadFailed = true
+		return m, statusMessageCmd(fmt.Sprintf("Failed to load history: %v", msg.err))
+	}
+	isCurrentItem := stackIndex == len(m.focusedWorkflowState.compactedHistoryStack)-1
+
+	// Keep the cursor on the same row while newer rows are added above it, unless it is still on the newest row
+	selectedEventId := int64(0)
+	if isCurrentItem && m.focusedWorkflowState.cursor > 0 {
+		compactHistorySlice := m.focusedWorkflowState.getCurrentCompactHistorySlice()
+		if m.focusedWorkflowState.cursor < len(compactHistorySlice) {
+			selectedEventId = compactHistorySlice[m.focusedWorkflowState.cursor].events[0].GetEventId()
+		}
+	}
+
+	// Events the item already holds are skipped so a page is never added twice
+	newEvents := []*history.HistoryEvent{}
+	for _, historyEvent := range msg.events {
+		if len(stackItem.history) == 0 || historyEvent.GetEventId() > stackItem.history[len(stackItem.history)-1].GetEventId() {
+			newEvents = append(newEvents, historyEvent)
+		}
+	}
+	stackItem.history = append(stackItem.history, newEvents...)
+	stackItem.compactHistory.addEvents(newEvents)
+	if stackItem.rawHistory != nil {
+		stackItem.rawHistory = append(stackItem.rawHistory, createRawHistory(newEvents)...)
+	}
+	stackItem.historyNextPageToken = msg.nextPageToken
+	stackItem.lastHistoryPageToken = msg.pageToken
+	// Activities that are scheduled in the later pages only get their pending state once everything is loaded
+	if len(msg.nextPageToken) == 0 {
+		stackItem.compactHistory.applyPendingActivities(stackItem.workflowDescription.GetPendingActivities())
+		stackItem.workflowTaskProblem = getWorkflowTaskProblem(stackItem.history, stackItem.workflowDescription)
+	}
+
+	if isCurrentItem && selectedEventId != 0 {
+		for i, compactHistoryItem := range m.focusedWorkflowState.getCurrentCompactHistorySlice() {
+			if compactHistoryItem.events[0].GetEventId() == selectedEventId {
+				m.focusedWorkflowState.cursor = i
+				break
+			}
+		}
+	}
+
+	if len(msg.nextPageToken) == 0 {
+		return m, nil
+	}
+	return m, m.loadHistoryPageCmd(msg.session, msg.workflowId, msg.runId, msg.nextPageToken)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ========================================
+// History Search
+// ========================================
+
+var historySearchMatchStyle = lipgloss.NewStyle().Padding(0, 0).Background(lipgloss.Color("#004466"))
+
+type historySearchState struct {
+	input textinput.Model
+	// The submitted query, empty when there is no active search
+	query string
+	// Hides the rows that do not match the query
+	filter bool
+	// Shared by the copies of the state, so the results computed while rendering are kept
+	results *historySearchResults
+}
+
+// Results of the search over the rows of a view, recomputed when the query, the filter or the rows change
+type historySearchResults struct {
+	query         string
+	filter        bool
+	items         []*compactHistoryListItem
+	version       int
+	filteredItems []*compactHistoryListItem
+	matchCount    int
+}
+
+func newHistorySearchState() historySearchState {
+	input := textinput.New()
+	input.Prompt = "/"
+	return historySearchState{input: input, results: &historySearchResults{}}
+}
+
+func isSameItemsSlice(a []*compactHistoryListItem, b []*compactHistoryListItem) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// version is bumped by the compacted history whenever its rows change
+func (s historySearchState) getResults(items []*compactHistoryListItem, version int) *historySearchResults {
+	results := s.results
+	if results == nil {
+		results = &historySearchResults{}
+	} else if results.query == s.query && results.filter == s.filter && results.version == version && isSameItemsSlice(results.items, items) {
+		return results
+	}
+	matchCount := 0
+	if s.query != "" {
+		for _, item := range items {
+			if s.matches(item) {
+				matchCount++
+			}
+		}
+	}
+	*results = historySearchResults{
+		query:         s.query,
+		filter:        s.filter,
+		items:         items,
+		version:       version,
+		filteredItems: s.filterItems(items),
+		matchCount:    matchCount,
+	}
+	return results
+}
+
+// Matches activity and signal names, event ids and payload content
+func (s historySearchState) matches(item *compactHistoryListItem) bool {
+	if s.query == "" {
+		return true
+	}
+	query := strings.ToLower(s.query)
+	if strings.Contains(strings.ToLower(item.rowContent), query) || strings.Contains(strings.ToLower(item.actionType), query) {
+		return true
+	}
+	if eventId, err := strconv.ParseInt(s.query, 10, 64); err == nil {
+		for _, historyEvent := range item.events {
+			if historyEvent.GetEventId() == eventId {
+				return true
+			}
+		}
+	}
+	for _, content := range item.eventsContent {
+		if strings.Contains(strings.ToLower(content.eventData), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s historySearchState) filterItems(items []*compactHistoryListItem) []*compactHistoryListItem {
+	if !s.filter || s.query == "" {
+		return items
+	}
+	filteredItems := []*compactHistoryListItem{}
+	for _, item := range items {
+		if s.matches(item) {
+			filteredItems = append(filteredItems, item)
+		}
+	}
+	return filteredItems
+}
+
+// Moves the cursor to the next matching row in the direction, wrapping around at the ends
+func (m *focusedModeState) jumpToMatch(direction int) bool {
+	compactHistorySlice := m.getCurrentCompactHistorySlice()
+	for i := 1; i <= len(compactHistorySlice); i++ {
+		index := ((m.cursor+direction*i)%len(compactHistorySlice) + len(compactHistorySlice)) % len(compactHistorySlice)
+		if m.historySearch.matches(compactHistorySlice[index]) {
+			m.cursor = index
+			m.payloadCursor = 0
+			return true
+		}
+	}
+	return false
+}
+
+// Handles the keys while the search input is focused
+func (m *model) updateHistorySearchInput(msg tea.KeyMsg) tea.Cmd {
+	search := &m.focusedWorkflowState.historySearch
+	if key.Matches(msg, m.focusedWorkflowState.keys.Exit) {
+		return tea.Quit
+	}
+	switch msg.String() {
+	case "enter":
+		search.input.Blur()
+		search.query = search.input.Value()
+		if search.query == "" {
+			search.filter = false
+			return nil
+		}
+		// Start from the row above the top so the first match can be the first row
+		m.focusedWorkflowState.cursor = -1
+		if search.filter {
+			m.focusedWorkflowState.cursor = 0
+			return nil
+		}
+		if !m.focusedWorkflowState.jumpToMatch(1) {
+			m.focusedWorkflowState.cursor = 0
+			return statusMessageCmd(fmt.Sprintf("No rows match %q", search.query))
+		}
+		return nil
+	case "esc":
+		search.input.Blur()
+		search.input.SetValue(search.query)
+		return nil
+	}
+	var cmd tea.Cmd
+	search.input, cmd = search.input.Update(msg)
+	return cmd
+}
+
+// Handles the search keys, returns false for keys that are not search keys
+func (m *model) updateHistorySearch(msg tea.KeyMsg) (bool, tea.Cmd) {
+	keys := m.focusedWorkflowState.keys
+	search := &m.focusedWorkflowState.historySearch
+	switch {
+	case key.Matches(msg, keys.SearchHistory):
+		search.input.SetValue(search.query)
+		search.input.CursorEnd()
+		return true, search.input.Focus()
+	case key.Matches(msg, keys.NextMatch), key.Matches(msg, keys.PreviousMatch):
+		if search.query == "" {
+			return true, statusMessageCmd("No active search, press / to search")
+		}
+		direction := 1
+		if key.Matches(msg, keys.PreviousMatch) {
+			direction = -1
+		}
+		if !m.focusedWorkflowState.jumpToMatch(direction) {
+			return true, statusMessageCmd(fmt.Sprintf("No rows match %q", search.query))
+		}
+		return true, nil
+	case key.Matches(msg, keys.ToggleSearchFilter):
+		if search.query == "" {
+			return true, statusMessageCmd("No active search, press / to search")
+		}
+		search.filter = !search.filter
+		m.focusedWorkflowState.resetCursors()
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m model) renderHistorySearchStatus() string {
+	search := m.focusedWorkflowState.historySearch
+	if search.input.Focused() {
+		return search.input.View()
+	}
+	if search.query == "" {
+		return ""
+	}
+	status := fmt.Sprintf("/%s (%d matches)", search.query, m.focusedWorkflowState.getCurrentHistorySearchResults().matchCount)
+	if search.filter {
+		status += " [filtered]"
+	}
+	return status
+}
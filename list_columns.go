@@ -0,0 +1,319 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"go.temporal.io/api/common/v1"
+)
+
+// ========================================
+// List Columns
+// ========================================
+
+// Columns are written as "<column> [width]", e.g. "workflow_id 40" or "search_attribute:CustomerId"
+var DEFAULT_LIST_COLUMNS = []string{"status", "type", "workflow_id", "start_time", "close_time", "duration", "last_event", "attempts"}
+
+var defaultListColumns, _ = parseListColumns(DEFAULT_LIST_COLUMNS)
+
+const SEARCH_ATTRIBUTE_COLUMN_PREFIX = "search_attribute:"
+const MEMO_COLUMN_PREFIX = "memo:"
+
+// Columns without a width are not shrunk below this when the table does not fit
+const LIST_COLUMN_MIN_WIDTH = 6
+
+type listColumn struct {
+	id     string
+	header string
+	// 0 sizes the column to its content
+	width int
+	// Ids are truncated from the start, their suffix is usually what tells them apart
+	keepSuffix bool
+	// Visibility field used in ORDER BY, empty when the column can not be sorted
+	sortField string
+	value     func(m model, w *workflowTableListItem) string
+}
+
+type listColumnsEditedMsg struct {
+	path string
+	err  error
+}
+
+var builtinListColumns = map[string]listColumn{
+	"status": {header: "Status", value: func(m model, w *workflowTableListItem) string {
+		childIcon := ""
+		if w.workflow.GetParentExecution().GetWorkflowId() != "" {
+			childIcon = "👶"
+		}
+		selectedIcon := ""
+		if m.selected[w.workflow.GetExecution().GetRunId()] != nil {
+			selectedIcon = "●"
+		}
+		return selectedIcon + statusToStyleMap[w.workflow.GetStatus().String()].icon + childIcon
+	}},
+	"type": {header: "Type", sortField: "WorkflowType", value: func(m model, w *workflowTableListItem) string {
+		return w.workflow.GetType().GetName()
+	}},
+	"workflow_id": {header: "Id", keepSuffix: true, value: func(m model, w *workflowTableListItem) string {
+		return w.workflow.GetExecution().GetWorkflowId()
+	}},
+	"run_id": {header: "Run Id", keepSuffix: true, value: func(m model, w *workflowTableListItem) string {
+		return w.workflow.GetExecution().GetRunId()
+	}},
+	"parent_id": {header: "Parent Id", keepSuffix: true, value: func(m model, w *workflowTableListItem) string {
+		return w.workflow.GetParentExecution().GetWorkflowId()
+	}},
+	"task_queue": {header: "Task Queue", value: func(m model, w *workflowTableListItem) string {
+		return w.workflow.GetTaskQueue()
+	}},
+	"start_time": {header: "Start Time", sortField: "StartTime", value: func(m model, w *workflowTableListItem) string {
+		return getRelativeTimeDiff(time.Now(), w.workflow.GetStartTime().AsTime())
+	}},
+	"execution_time": {header: "Execution Time", sortField: "ExecutionTime", value: func(m model, w *workflowTableListItem) string {
+		if w.workflow.GetExecutionTime() == nil {
+			return "--"
+		}
+		return getRelativeTimeDiff(time.Now(), w.workflow.GetExecutionTime().AsTime())
+	}},
+	"close_time": {header: "Close Time", sortField: "CloseTime", value: func(m model, w *workflowTableListItem) string {
+		// Running workflows have no close time
+		if w.workflow.GetCloseTime() == nil {
+			return "--"
+		}
+		return getRelativeTimeDiff(time.Now(), w.workflow.GetCloseTime().AsTime())
+	}},
+	"duration": {header: "Duration", value: func(m model, w *workflowTableListItem) string {
+		return renderWorkflowDuration(w)
+	}},
+	"last_event": {header: "Last Event", value: func(m model, w *workflowTableListItem) string {
+		return renderWorkflowLastEvent(w)
+	}},
+	"history_length": {header: "Events", value: func(m model, w *workflowTableListItem) string {
+		return strconv.FormatInt(w.workflow.GetHistoryLength(), 10)
+	}},
+	"attempts": {header: "Attempts", value: func(m model, w *workflowTableListItem) string {
+		attempts := strconv.Itoa(int(w.attempts))
+		if w.attempts > 3 {
+			attempts = attemptsStyle.Render(attempts)
+		}
+		if w.attempts == 0 {
+			attempts = "--"
+		}
+		if w.workflowTaskAttempts > 1 {
+			attempts = attemptsStyle.Render(formatWorkflowTaskAttempts(w.workflowTaskAttempts)) + " " + attempts
+		}
+		return attempts
+	}},
+}
+
+// Two largest units only, e.g. "3d 4h" or "5m 12s"
+func formatListDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// Strings are shown without their quotes, other values as compact json
+func formatListPayload(payload *common.Payload) string {
+	if payload == nil {
+		return "--"
+	}
+	value := strings.Join(strings.Fields(convertDataToPrettyJSON(payload.GetData())), " ")
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
+
+func parseListColumn(spec string) (listColumn, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 || len(fields) > 2 {
+		return listColumn{}, fmt.Errorf("invalid column %q, expected \"<column> [width]\"", spec)
+	}
+	id := fields[0]
+	var column listColumn
+	switch {
+	case strings.HasPrefix(id, SEARCH_ATTRIBUTE_COLUMN_PREFIX):
+		name := strings.TrimPrefix(id, SEARCH_ATTRIBUTE_COLUMN_PREFIX)
+		column = listColumn{header: name, sortField: name, value: func(m model, w *workflowTableListItem) string {
+			return formatListPayload(w.workflow.GetSearchAttributes().GetIndexedFields()[name])
+		}}
+	case strings.HasPrefix(id, MEMO_COLUMN_PREFIX):
+		name := strings.TrimPrefix(id, MEMO_COLUMN_PREFIX)
+		column = listColumn{header: name, value: func(m model, w *workflowTableListItem) string {
+			return formatListPayload(w.workflow.GetMemo().GetFields()[name])
+		}}
+	default:
+		builtinColumn, ok := builtinListColumns[id]
+		if !ok {
+			return listColumn{}, fmt.Errorf("unknown column %q", id)
+		}
+		column = builtinColumn
+	}
+	column.id = id
+	if len(fields) == 2 {
+		width, err := strconv.Atoi(fields[1])
+		if err != nil || width < 1 {
+			return listColumn{}, fmt.Errorf("invalid width %q for column %q", fields[1], id)
+		}
+		column.width = width
+	}
+	return column, nil
+}
+
+func parseListColumns(specs []string) ([]listColumn, error) {
+	if len(specs) == 0 {
+		specs = DEFAULT_LIST_COLUMNS
+	}
+	columns := []listColumn{}
+	for _, spec := range specs {
+		column, err := parseListColumn(spec)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func formatListColumnSpecs(columns []listColumn) []string {
+	specs := []string{}
+	for _, column := range columns {
+		spec := column.id
+		if column.width > 0 {
+			spec += " " + strconv.Itoa(column.width)
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// Shrinks the widest columns without a width until the table fits, the separators take one cell per column
+func getListColumnWidths(columns []listColumn, headers []string, rows [][]string, availableWidth int) []int {
+	widths := make([]int, len(columns))
+	total := len(columns) - 1
+	for i, column := range columns {
+		widths[i] = column.width
+		if column.width == 0 {
+			widths[i] = lipgloss.Width(headers[i])
+			for _, row := range rows {
+				widths[i] = max(widths[i], lipgloss.Width(row[i]))
+			}
+		}
+		total += widths[i]
+	}
+	for total > availableWidth {
+		widest := -1
+		for i, column := range columns {
+			if column.width == 0 && widths[i] > LIST_COLUMN_MIN_WIDTH && (widest == -1 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest == -1 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+func truncateListCell(value string, width int, keepSuffix bool) string {
+	if lipgloss.Width(value) <= width {
+		return value
+	}
+	if keepSuffix {
+		return ansi.TruncateLeft(value, lipgloss.Width(value)-width+1, "…")
+	}
+	return ansi.Truncate(value, width, "…")
+}
+
+// Returns the headers and the rows of the list, truncated to fit in the width
+func (m model) getListTableCells(workflows []*workflowTableListItem, availableWidth int) ([]string, [][]string) {
+	headers := []string{}
+	for _, column := range m.listColumns {
+		headers = append(headers, column.header+m.listSort.getHeaderIndicator(column))
+	}
+	rows := [][]string{}
+	for _, w := range workflows {
+		row := []string{}
+		for _, column := range m.listColumns {
+			row = append(row, column.value(m, w))
+		}
+		rows = append(rows, row)
+	}
+	widths := getListColumnWidths(m.listColumns, headers, rows, availableWidth)
+	for i, column := range m.listColumns {
+		headers[i] = truncateListCell(headers[i], widths[i], false)
+		for _, row := range rows {
+			row[i] = truncateListCell(row[i], widths[i], column.keepSuffix)
+		}
+	}
+	return headers, rows
+}
+
+// Opens the columns in $EDITOR, one per line, the columns are replaced once the editor is closed
+func (m model) editListColumnsCmd() tea.Cmd {
+	if os.Getenv("EDITOR") == "" {
+		return statusMessageCmd("Set $EDITOR to edit columns")
+	}
+	builtinColumnIds := []string{}
+	for id := range builtinListColumns {
+		builtinColumnIds = append(builtinColumnIds, id)
+	}
+	sort.Strings(builtinColumnIds)
+	lines := []string{
+		"# One column per line: <column> [width]",
+		"# Columns: " + strings.Join(builtinColumnIds, ", "),
+		"# Search attributes and memo fields: " + SEARCH_ATTRIBUTE_COLUMN_PREFIX + "<name>, " + MEMO_COLUMN_PREFIX + "<name>",
+		"# Save the columns to `columns` in the namespace config to keep them",
+	}
+	lines = append(lines, formatListColumnSpecs(m.listColumns)...)
+	return editInEditorCmd("columns", strings.Join(lines, "\n")+"\n", func(path string, err error) tea.Msg {
+		return listColumnsEditedMsg{path: path, err: err}
+	})
+}
+
+func (m model) handleListColumnsEditedMsg(msg listColumnsEditedMsg) (tea.Model, tea.Cmd) {
+	defer os.Remove(msg.path)
+	if msg.err != nil {
+		return m, statusMessageCmd(fmt.Sprintf("Editor exited with error: %v", msg.err))
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		return m, statusMessageCmd(fmt.Sprintf("Failed to read columns: %v", err))
+	}
+	specs := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			specs = append(specs, line)
+		}
+	}
+	columns, err := parseListColumns(specs)
+	if err != nil {
+		return m, statusMessageCmd(err.Error())
+	}
+	m.listColumns = columns
+	return m, nil
+}
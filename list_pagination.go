@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ========================================
+// List Pagination
+// ========================================
+
+// Used when neither the -page-size flag nor page_size in the namespace config is set
+const DEFAULT_TABLE_LIST_PAGE_SIZE = 40
+
+type jumpToPageMsg struct {
+	page int
+	// Page tokens found on the way to the page
+	nextPageTokens map[int][]byte
+	err            error
+}
+
+func newPageInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Go to page: "
+	input.CharLimit = 9
+	return input
+}
+
+// The flag wins over the namespace config
+func getListPageSize(flagPageSize int, configPageSize int) int {
+	switch {
+	case flagPageSize > 0:
+		return flagPageSize
+	case configPageSize > 0:
+		return configPageSize
+	}
+	return DEFAULT_TABLE_LIST_PAGE_SIZE
+}
+
+// Query of the list including the ORDER BY of the current sort
+func (m model) getListQuery() string {
+	return strings.TrimSpace(m.constructQueryString() + " " + m.listSort.getOrderByClause())
+}
+
+// Zero until the count of the current query is known
+func (m model) getPageCount() int {
+	if m.totalWorkflowCount <= 0 {
+		return 0
+	}
+	return int((m.totalWorkflowCount + int64(m.pageSize) - 1) / int64(m.pageSize))
+}
+
+// The server returns no token after the last page
+func (m model) isLastPage() bool {
+	nextPageToken, ok := m.nextPageTokenCache[m.page+1]
+	return ok && len(nextPageToken) == 0
+}
+
+func (m model) renderPagePosition() string {
+	if pageCount := m.getPageCount(); pageCount > 0 {
+		return fmt.Sprintf("Page %d of %d", m.page+1, pageCount)
+	}
+	return fmt.Sprintf("Page %d", m.page+1)
+}
+
+func (m model) nextPage() (model, tea.Cmd) {
+	if pageCount := m.getPageCount(); m.isLastPage() || (pageCount > 0 && m.page+1 >= pageCount) {
+		return m, statusMessageCmd("Already on the last page")
+	}
+	// Without the token of the next page the list would start again from the first page
+	if _, ok := m.nextPageTokenCache[m.page+1]; !ok {
+		return m, statusMessageCmd("Page is still loading")
+	}
+	m.page++
+	m.cursor = 0
+	return m, m.refetchWorkflowsCmd()
+}
+
+func (m model) previousPage() (model, tea.Cmd) {
+	if m.page == 0 {
+		return m, statusMessageCmd("Already on the first page")
+	}
+	m.page--
+	m.cursor = 0
+	return m, m.refetchWorkflowsCmd()
+}
+
+// Page tokens can only be fetched in order, so every page between the last known page and the target is listed
+func (m *model) jumpToPageCmd(page int) tea.Cmd {
+	return func() tea.Msg {
+		temporalClient, _ := m.getTemporalClient()
+		knownPage := 0
+		for cachedPage := range m.nextPageTokenCache {
+			if cachedPage <= page && cachedPage > knownPage {
+				knownPage = cachedPage
+			}
+		}
+		nextPageTokens := map[int][]byte{}
+		nextPageToken := m.nextPageTokenCache[knownPage]
+		for ; knownPage < page; knownPage++ {
+			// The previous page was the last one
+			if knownPage > 0 && len(nextPageToken) == 0 {
+				break
+			}
+			response, err := temporalClient.ListWorkflow(context.Background(), &workflowservice.ListWorkflowExecutionsRequest{
+				Query:         m.getListQuery(),
+				PageSize:      int32(m.pageSize),
+				NextPageToken: nextPageToken,
+			})
+			if err != nil {
+				return jumpToPageMsg{err: err}
+			}
+			nextPageToken = response.GetNextPageToken()
+			nextPageTokens[knownPage+1] = nextPageToken
+		}
+		return jumpToPageMsg{page: knownPage, nextPageTokens: nextPageTokens}
+	}
+}
+
+func (m model) handleJumpToPageMsg(msg jumpToPageMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, statusMessageCmd(fmt.Sprintf("Failed to go to page: %v", msg.err))
+	}
+	for page, nextPageToken := range msg.nextPageTokens {
+		m.nextPageTokenCache[page] = nextPageToken
+	}
+	// The last page is the page before the first empty token
+	if len(m.nextPageTokenCache[msg.page]) == 0 && msg.page > 0 {
+		msg.page--
+	}
+	m.page = msg.page
+	m.cursor = 0
+	return m, m.refetchWorkflowsCmd()
+}
+
+// Handles the keys while the page input is focused
+func (m model) updatePageInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Exit) {
+		return m, tea.Quit
+	}
+	switch msg.String() {
+	case "enter":
+		m.pageInput.Blur()
+		page, err := strconv.Atoi(strings.TrimSpace(m.pageInput.Value()))
+		m.pageInput.SetValue("")
+		pageCount := m.getPageCount()
+		if err != nil || page < 1 || (pageCount > 0 && page > pageCount) {
+			return m, statusMessageCmd(fmt.Sprintf("Enter a page between 1 and %d", max(pageCount, 1)))
+		}
+		return m, m.jumpToPageCmd(page - 1)
+	case "esc":
+		m.pageInput.Blur()
+		m.pageInput.SetValue("")
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.pageInput, cmd = m.pageInput.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ========================================
+// List Sort
+// ========================================
+
+type listSortState struct {
+	// Visibility field of the sorted column, empty for the server default order
+	field      string
+	descending bool
+	// Fields the visibility store can not ORDER BY, these are sorted within the current page
+	unsupportedFields map[string]bool
+}
+
+func (s listSortState) isSortedWithinPage() bool {
+	return s.field != "" && s.unsupportedFields[s.field]
+}
+
+// Appended to the list query, the counts use the query without it
+func (s listSortState) getOrderByClause() string {
+	if s.field == "" || s.isSortedWithinPage() {
+		return ""
+	}
+	direction := "ASC"
+	if s.descending {
+		direction = "DESC"
+	}
+	return "ORDER BY " + s.field + " " + direction
+}
+
+// Visibility stores without ORDER BY support reject the query as an invalid argument about the sorting
+func isOrderByUnsupportedError(err error) bool {
+	var invalidArgument *serviceerror.InvalidArgument
+	if !errors.As(err, &invalidArgument) {
+		return false
+	}
+	message := strings.ToLower(invalidArgument.Error())
+	return strings.Contains(message, "order by") || strings.Contains(message, "sort")
+}
+
+func (s listSortState) getHeaderIndicator(column listColumn) string {
+	if column.sortField == "" || column.sortField != s.field {
+		return ""
+	}
+	if s.descending {
+		return " ▼"
+	}
+	return " ▲"
+}
+
+func getListSortTime(w *workflowTableListItem, field string) *timestamppb.Timestamp {
+	switch field {
+	case "StartTime":
+		return w.workflow.GetStartTime()
+	case "CloseTime":
+		return w.workflow.GetCloseTime()
+	case "ExecutionTime":
+		return w.workflow.GetExecutionTime()
+	}
+	return nil
+}
+
+// Numbers are compared as numbers, everything else as text
+func compareListSortValues(a string, b string) int {
+	aNumber, aErr := strconv.ParseFloat(a, 64)
+	bNumber, bErr := strconv.ParseFloat(b, 64)
+	switch {
+	case aErr == nil && bErr == nil && aNumber < bNumber:
+		return -1
+	case aErr == nil && bErr == nil && aNumber > bNumber:
+		return 1
+	case aErr == nil && bErr == nil:
+		return 0
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareListItems(a *workflowTableListItem, b *workflowTableListItem, field string) int {
+	switch field {
+	case "StartTime", "CloseTime", "ExecutionTime":
+		// Running workflows have no close time, they are the newest
+		aTime, bTime := getListSortTime(a, field), getListSortTime(b, field)
+		switch {
+		case aTime == nil && bTime == nil:
+			return 0
+		case aTime == nil:
+			return 1
+		case bTime == nil:
+			return -1
+		}
+		return aTime.AsTime().Compare(bTime.AsTime())
+	case "WorkflowType":
+		return compareListSortValues(a.workflow.GetType().GetName(), b.workflow.GetType().GetName())
+	}
+	return compareListSortValues(
+		formatListPayload(a.workflow.GetSearchAttributes().GetIndexedFields()[field]),
+		formatListPayload(b.workflow.GetSearchAttributes().GetIndexedFields()[field]),
+	)
+}
+
+// Fallback for visibility stores without ORDER BY support
+func (s listSortState) sortWithinPage(workflows []*workflowTableListItem) {
+	if !s.isSortedWithinPage() {
+		return
+	}
+	sort.SliceStable(workflows, func(i, j int) bool {
+		comparison := compareListItems(workflows[i], workflows[j], s.field)
+		if s.descending {
+			return comparison > 0
+		}
+		return comparison < 0
+	})
+}
+
+// Sorts by the next sortable column, after the last column the server default order is used again
+func (m model) cycleListSortColumn() (model, tea.Cmd) {
+	sortFields := []string{}
+	for _, column := range m.listColumns {
+		if column.sortField != "" {
+			sortFields = append(sortFields, column.sortField)
+		}
+	}
+	if len(sortFields) == 0 {
+		return m, statusMessageCmd("None of the columns can be sorted")
+	}
+	nextField := sortFields[0]
+	for i, field := range sortFields {
+		if field == m.listSort.field {
+			nextField = ""
+			if i+1 < len(sortFields) {
+				nextField = sortFields[i+1]
+			}
+		}
+	}
+	m.listSort.field = nextField
+	m.listSort.descending = true
+	return m.applyListSort()
+}
+
+func (m model) reverseListSort() (model, tea.Cmd) {
+	if m.listSort.field == "" {
+		return m, statusMessageCmd("The list is not sorted, press O to sort")
+	}
+	m.listSort.descending = !m.listSort.descending
+	return m.applyListSort()
+}
+
+// The page tokens belong to the previous order, so the list starts again from the first page
+func (m model) applyListSort() (model, tea.Cmd) {
+	if m.listSort.isSortedWithinPage() {
+		m.listSort.sortWithinPage(m.workflows)
+		return m, nil
+	}
+	m.clearListState()
+	return m, m.refetchWorkflowsCmd()
+}
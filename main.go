@@ -2,10 +2,10 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
@@ -58,6 +58,15 @@ type KeyMap struct {
 	FocusWorkflow            key.Binding
 	NextPage                 key.Binding
 	PrevPage                 key.Binding
+	YankWorkflowId           key.Binding
+	YankRunId                key.Binding
+	YankRowJson              key.Binding
+	ShowRuns                 key.Binding
+	DiffWorkflows            key.Binding
+	EditColumns              key.Binding
+	SortByNextColumn         key.Binding
+	GoToPage                 key.Binding
+	ReverseSort              key.Binding
 }
 
 var DefaultKeyMap = KeyMap{
@@ -99,7 +108,7 @@ var DefaultKeyMap = KeyMap{
 	),
 	Select: key.NewBinding(
 		key.WithKeys("enter", "space"),
-		key.WithHelp("enter/space", "toggle selection"),
+		key.WithHelp("enter/space", "select for diff"),
 	),
 	OpenWorkflowInWeb: key.NewBinding(
 		key.WithKeys("o"),
@@ -129,6 +138,42 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("["),
 		key.WithHelp("[", "Go to previous page"),
 	),
+	YankWorkflowId: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy workflow id"),
+	),
+	YankRunId: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy run id"),
+	),
+	YankRowJson: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "copy row as json"),
+	),
+	ShowRuns: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "show all runs"),
+	),
+	DiffWorkflows: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "diff selected workflows"),
+	),
+	EditColumns: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "edit columns"),
+	),
+	GoToPage: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "go to page"),
+	),
+	SortByNextColumn: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "sort by next column"),
+	),
+	ReverseSort: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "reverse sort"),
+	),
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view. It's part
@@ -143,7 +188,8 @@ func (k KeyMap) ShortHelp() []key.Binding {
 // key.Map interface.
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.SearchWorkflowType, k.SearchExecutionStatus, k.SearchWorkflowId, k.ToggleParentWorkflowMode, k.OpenWorkflowInWeb, k.ClearSearch, k.RefetchWorkflows, k.RestartWorkflow, k.TerminateWorkflow, k.Exit, k.NextPage, k.PrevPage},
+		{k.Up, k.Down, k.SearchWorkflowType, k.SearchExecutionStatus, k.SearchWorkflowId, k.ToggleParentWorkflowMode, k.OpenWorkflowInWeb, k.ClearSearch, k.RefetchWorkflows, k.RestartWorkflow, k.TerminateWorkflow, k.Exit, k.NextPage, k.PrevPage, k.GoToPage},
+		{k.YankWorkflowId, k.YankRunId, k.YankRowJson, k.ShowRuns, k.DiffWorkflows, k.EditColumns, k.SortByNextColumn, k.ReverseSort},
 	}
 }
 
@@ -162,8 +208,6 @@ var temporalEnumStatusList = []string{
 	// I removed the CONTINUED_AS_NEW status
 }
 
-var TABLE_LIST_PAGE_SIZE = 40
-
 type ExecutionStatusStyleInfo struct {
 	displayName string
 	icon        string
@@ -223,6 +267,8 @@ type confirmationFlowStateMsg struct {
 	executionSuccessMessage       string
 	areYouSureMessage             string
 	commandThatRunsOnConfirmation tea.Cmd
+	// Runs once the action completed, the list is reset when it is not set
+	onCompletedCmd tea.Cmd
 }
 
 func (m model) startConfirmationMessageFlowCmd(confirmationFlowStateMsg confirmationFlowStateMsg) tea.Cmd {
@@ -236,6 +282,31 @@ func (m model) startConfirmationMessageFlowCmd(confirmationFlowStateMsg confirma
 	}
 }
 
+// ========================================
+// Status Messages
+// ========================================
+
+// Short lived message shown in the footer (e.g. after copying to the clipboard)
+type statusMessageMsg struct {
+	message string
+}
+
+type clearStatusMessageMsg struct {
+	message string
+}
+
+func statusMessageCmd(message string) tea.Cmd {
+	return func() tea.Msg {
+		return statusMessageMsg{message: message}
+	}
+}
+
+func clearStatusMessageCmd(message string) tea.Cmd {
+	return tea.Tick(time.Second*3, func(_ time.Time) tea.Msg {
+		return clearStatusMessageMsg{message: message}
+	})
+}
+
 // ========================================
 // Screen Constants
 // ========================================
@@ -373,17 +444,29 @@ func (m model) constructQueryString() string {
 	return queryString
 }
 
-func (m model) renderFooter() string {
-	if m.confirmationFlowState.state == EXECUTING_ACTION {
-		return m.confirmationFlowState.pendingConfirmationMessage + "..."
+func (m model) renderConfirmationFlowFooter() (string, bool) {
+	switch m.confirmationFlowState.state {
+	case EXECUTING_ACTION:
+		return m.confirmationFlowState.pendingConfirmationMessage + "...", true
+	case ACTION_COMPLETED:
+		return m.confirmationFlowState.executionSuccessMessage, true
+	case AWAITING_CONFIRMATION:
+		return m.confirmationFlowState.areYouSureMessage + " (y/n)", true
 	}
-	if m.confirmationFlowState.state == ACTION_COMPLETED {
-		return m.confirmationFlowState.executionSuccessMessage
+	return "", false
+}
+
+func (m model) renderFooter() string {
+	if confirmationFooter, ok := m.renderConfirmationFlowFooter(); ok {
+		return confirmationFooter
 	}
-	if m.confirmationFlowState.state == AWAITING_CONFIRMATION {
-		return m.confirmationFlowState.areYouSureMessage + " (y/n)"
+	if m.statusMessage != "" {
+		return m.statusMessage
 	}
 	helpView := m.help.View(m.keys)
+	if m.pageInput.Focused() {
+		return textInputWrapperStyle.Width(m.viewport.Width).Render(m.pageInput.View())
+	}
 	if m.searchMode == "" {
 		return helpView
 	}
@@ -413,7 +496,6 @@ func (m *model) setFocusedWorkflowCmd(workflowId string, runId string) tea.Cmd {
 	return func() tea.Msg {
 		temporalClient, _ := m.getTemporalClient()
 		executionDescription, err := temporalClient.DescribeWorkflowExecution(context.Background(), workflowId, runId)
-		historyIterator := temporalClient.GetWorkflowHistory(context.Background(), workflowId, runId, false, 0)
 
 		pendingActivities := executionDescription.GetPendingActivities()
 		// Nested loop. We break out of the loop if we find an activity with an attempt > 0
@@ -421,20 +503,21 @@ func (m *model) setFocusedWorkflowCmd(workflowId string, runId string) tea.Cmd {
 		if err != nil {
 			log.Fatalf("Failed to describe workflow: %v", err)
 		}
-		history := []*history.HistoryEvent{}
-		for historyIterator.HasNext() {
-			historyEvent, err := historyIterator.Next()
-			if err != nil {
-				log.Fatalf("Failed to get workflow history: %v", err)
-			}
-			history = append(history, historyEvent)
+		// Only the first page is loaded here so large histories open right away
+		historyPage, err := m.getHistoryPage(workflowId, executionDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId(), nil)
+		if err != nil {
+			log.Fatalf("Failed to get workflow history: %v", err)
 		}
-		compactedHistory := createCompactHistory(history, pendingActivities)
+		historyEvents := historyPage.GetHistory().GetEvents()
+		compactedHistory := createCompactHistory(historyEvents, pendingActivities)
 		newCompactedHistoryStackItem := compactHistoryStackItem{
-			workflowId:          workflowId,
-			runId:               runId,
-			compactHistory:      compactedHistory,
-			workflowDescription: executionDescription,
+			workflowId:           workflowId,
+			runId:                runId,
+			history:              historyEvents,
+			compactHistory:       compactedHistory,
+			workflowDescription:  executionDescription,
+			workflowTaskProblem:  getWorkflowTaskProblem(historyEvents, executionDescription),
+			historyNextPageToken: historyPage.GetNextPageToken(),
 		}
 		return setFocusedWorkflowMsg{compactedHistoryStackItem: newCompactedHistoryStackItem}
 	}
@@ -522,6 +605,12 @@ func (m model) renderHeader() string {
 	queryStringStyle := lipgloss.NewStyle().Padding(0, 0).Width(m.viewport.Width).Height(1)
 	// Construct the count string
 	currentQuery := m.constructQueryString()
+	if orderBy := m.listSort.getOrderByClause(); orderBy != "" {
+		currentQuery += " " + orderBy
+	}
+	if m.listSort.isSortedWithinPage() {
+		currentQuery += " (sorted by " + m.listSort.field + " within the page)"
+	}
 	// Order the upToDateWorkflowCount map by the order of the temporalEnums
 	// This is to ensure that the order of the counts is consistent
 
@@ -539,7 +628,7 @@ func (m model) renderHeader() string {
 
 	row := lipgloss.JoinHorizontal(lipgloss.Top, styleStrArray...)
 
-	return headerStyle.Render(row + "\n" + queryStringStyle.Render(currentQuery))
+	return headerStyle.Render(row + "\n" + queryStringStyle.Render(m.renderPagePosition()+"  "+currentQuery))
 }
 
 var HeaderStyle = lipgloss.NewStyle().Padding(0, 0).Bold(true)
@@ -552,6 +641,7 @@ var highlightedStatusIconStyle = lipgloss.NewStyle().Background(lipgloss.Color("
 var attemptsStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
 
 func (m model) renderTable(workflows []*workflowTableListItem) string {
+	headers, rows := m.getListTableCells(workflows, m.viewport.Width)
 	helpHeight := lipgloss.Height(m.help.View(m.keys))
 	tableSurroundStyle := lipgloss.NewStyle().Padding(0, 0).Height(m.viewport.Height - HEADER_HEIGHT - helpHeight)
 	t := table.New().
@@ -573,29 +663,9 @@ func (m model) renderTable(workflows []*workflowTableListItem) string {
 				return OddRowStyle
 			}
 		}).
-		Headers("Status", "Type", "Id", "Start Time", "Close Time", "Attempts")
-	for _, w := range workflows {
-		workflowId := w.workflow.Execution.WorkflowId
-		closeTime := w.workflow.GetCloseTime().AsTime().In(time.Local).Format(time.RFC3339)
-		// If close time starts with 1970, it means the workflow is still running and has no close time
-		if w.workflow.GetStatus().String() == "Running" {
-			closeTime = "--"
-		}
-		attempts := strconv.Itoa(int(w.attempts))
-		if w.attempts > 3 {
-			attempts = attemptsStyle.Render(attempts)
-		}
-		if w.attempts == 0 {
-			attempts = "--"
-		}
-		childIcon := ""
-		if w.workflow.GetParentExecution().GetWorkflowId() != "" {
-			childIcon = "👶"
-		}
-		statusIcon := statusToStyleMap[w.workflow.GetStatus().String()].icon
-		startTimeDiff := getRelativeTimeDiff(time.Now(), w.workflow.GetStartTime().AsTime())
-
-		t.Row(statusIcon+childIcon, w.workflow.GetType().Name, workflowId, startTimeDiff, closeTime, attempts)
+		Headers(headers...)
+	for _, row := range rows {
+		t.Row(row...)
 	}
 	return tableSurroundStyle.Render(t.Render())
 }
@@ -618,8 +688,12 @@ func (m model) backgroundUpdateWorkflowCountCmd(exeuctionStatus temporalEnums.Wo
 }
 
 type updateWorkflowsMsg struct {
+	page          int
 	workflows     []*workflowTableListItem
 	nextPageToken []byte
+	totalCount    int64
+	// Set when the visibility store rejected the ORDER BY of this field
+	unsupportedSortField string
 }
 
 type refetchWorkflowCmdOptions struct {
@@ -630,15 +704,31 @@ func (m *model) refetchWorkflowsCmd() tea.Cmd {
 	return func() tea.Msg {
 		temporalClient, _ := m.getTemporalClient()
 		query := m.constructQueryString()
-		nextPageToken := m.nextPageTokenCache[m.page]
+		page := m.page
+		nextPageToken := m.nextPageTokenCache[page]
+		orderBy := m.listSort.getOrderByClause()
 		queryResult, err := temporalClient.ListWorkflow(context.Background(), &workflowservice.ListWorkflowExecutionsRequest{
-			Query:         query,
-			PageSize:      int32(TABLE_LIST_PAGE_SIZE),
+			Query:         strings.TrimSpace(query + " " + orderBy),
+			PageSize:      int32(m.pageSize),
 			NextPageToken: nextPageToken,
 		})
+		// Not every visibility store supports ORDER BY, the page is sorted after it is fetched instead
+		unsupportedSortField := ""
+		if orderBy != "" && isOrderByUnsupportedError(err) {
+			unsupportedSortField = m.listSort.field
+			queryResult, err = temporalClient.ListWorkflow(context.Background(), &workflowservice.ListWorkflowExecutionsRequest{
+				Query:         query,
+				PageSize:      int32(m.pageSize),
+				NextPageToken: nextPageToken,
+			})
+		}
 		if err != nil {
 			log.Fatalf("Failed to list workflows: %v", err)
 		}
+		totalCount := int64(-1)
+		if countResult, err := temporalClient.CountWorkflow(context.Background(), &workflowservice.CountWorkflowExecutionsRequest{Query: query}); err == nil {
+			totalCount = countResult.GetCount()
+		}
 		result := queryResult.GetExecutions()
 		returnObj := []*workflowTableListItem{}
 		for _, workflow := range result {
@@ -649,7 +739,7 @@ func (m *model) refetchWorkflowsCmd() tea.Cmd {
 		// Need the workflow list be up to date. tea.Sequence runs when the message is returned, not when the message is handled
 		// TODO: Restructure code so updateVisibleWorkflowAttempsBackgroundCmd runs after the updateWorkflowsMsg is handled
 		m.workflows = returnObj
-		return updateWorkflowsMsg{workflows: returnObj, nextPageToken: queryResult.NextPageToken}
+		return updateWorkflowsMsg{page: page, workflows: returnObj, nextPageToken: queryResult.NextPageToken, totalCount: totalCount, unsupportedSortField: unsupportedSortField}
 	}
 }
 
@@ -659,11 +749,14 @@ type updateVisibleWorkflowsMsg struct {
 
 type updateVisibleWorkflowAttempsMsg struct {
 	updateMapping map[string]int32
+	// Attempt of the pending workflow task, anything above 1 means the workflow task keeps failing
+	workflowTaskAttemptMapping map[string]int32
 }
 
 func (m *model) updateVisibleWorkflowAttempsBackgroundCmd(delay time.Duration) tea.Cmd {
 	return tea.Tick(time.Second*delay, func(_ time.Time) tea.Msg {
 		returnObj := make(map[string]int32)
+		workflowTaskAttemptMapping := make(map[string]int32)
 		temporalClient, _ := m.getTemporalClient()
 		currentRunningExecutionIds := []string{}
 		for _, execution := range m.workflows {
@@ -672,12 +765,12 @@ func (m *model) updateVisibleWorkflowAttempsBackgroundCmd(delay time.Duration) t
 			}
 		}
 		if len(currentRunningExecutionIds) == 0 {
-			return updateVisibleWorkflowAttempsMsg{updateMapping: returnObj}
+			return updateVisibleWorkflowAttempsMsg{updateMapping: returnObj, workflowTaskAttemptMapping: workflowTaskAttemptMapping}
 		}
 		query := fmt.Sprintf("WorkflowId IN (%s)", strings.Join(currentRunningExecutionIds, ","))
 		queryResult, err := temporalClient.ListWorkflow(context.Background(), &workflowservice.ListWorkflowExecutionsRequest{
 			Query:    query,
-			PageSize: int32(TABLE_LIST_PAGE_SIZE),
+			PageSize: int32(m.pageSize),
 		})
 		if err != nil {
 			log.Fatalf("Failed to list workflows: %v", err)
@@ -698,6 +791,7 @@ func (m *model) updateVisibleWorkflowAttempsBackgroundCmd(delay time.Duration) t
 				if err != nil {
 					break
 				}
+				workflowTaskAttemptMapping[workflow.GetExecution().WorkflowId] = execution.GetPendingWorkflowTask().GetAttempt()
 
 				pendingActivities := execution.GetPendingActivities()
 				// Nested loop. We break out of the loop if we find an activity with an attempt > 0
@@ -712,7 +806,7 @@ func (m *model) updateVisibleWorkflowAttempsBackgroundCmd(delay time.Duration) t
 				continue
 			}
 		}
-		return updateVisibleWorkflowAttempsMsg{updateMapping: returnObj}
+		return updateVisibleWorkflowAttempsMsg{updateMapping: returnObj, workflowTaskAttemptMapping: workflowTaskAttemptMapping}
 	})
 }
 
@@ -732,7 +826,7 @@ func (m model) updateVisibleWorkflowsBackgroundCmd() tea.Cmd {
 		query := fmt.Sprintf("WorkflowId IN (%s)", strings.Join(currentRunningExecutionIds, ","))
 		queryResult, err := temporalClient.ListWorkflow(context.Background(), &workflowservice.ListWorkflowExecutionsRequest{
 			Query:    query,
-			PageSize: int32(TABLE_LIST_PAGE_SIZE),
+			PageSize: int32(m.pageSize),
 		})
 		if err != nil {
 			log.Fatalf("Failed to list workflows: %v", err)
@@ -795,12 +889,18 @@ type workflowTableListItem struct {
 	history           []*history.HistoryEvent
 	pendingActivities []*workflow.PendingActivityInfo
 	attempts          int32
+	// Attempt of the pending workflow task (see workflowTaskProblem)
+	workflowTaskAttempts int32
+	// Time of the newest history event, zero until it is fetched
+	lastEventTime time.Time
 }
 
 type model struct {
 	focusedWorkflowState  focusedModeState
+	runsViewState         runsViewState
 	parentWorkflowMode    bool
 	confirmationFlowState confirmationFlowStateMsg
+	statusMessage         string
 	keys                  KeyMap
 	help                  help.Model
 	page                  int
@@ -812,8 +912,20 @@ type model struct {
 	ready                 bool
 	workflows             []*workflowTableListItem
 	cursor                int // which to-do list item our cursor is pointing at
-	selected              map[int]bool
-	viewport              viewport.Model
+	// Selected workflows by run id, they stay selected across pages
+	selected      map[string]*workflow.WorkflowExecutionInfo
+	diffViewState diffViewState
+	listColumns   []listColumn
+	listSort      listSortState
+	pageSize      int
+	pageInput     textinput.Model
+	// Count of the current query, -1 when it could not be counted
+	totalWorkflowCount int64
+	// Set when viewing a history file, nothing is fetched from the server
+	offline bool
+	// Whether the relative time tick of the list is scheduled
+	relativeTimeTicking bool
+	viewport            viewport.Model
 	// This is the workflow count that is up to date in the background
 	upToDateWorkflowCount map[temporalEnums.WorkflowExecutionStatus]int64
 }
@@ -833,8 +945,16 @@ func initialModel() model {
 		page:               0,
 		focusedWorkflowState: focusedModeState{
 			keys:                  FocusedModeKeyMap,
+			viewMode:              COMPACT_VIEW,
+			historySearch:         newHistorySearchState(),
 			compactedHistoryStack: make([]compactHistoryStackItem, 0),
 		},
+		runsViewState: runsViewState{
+			keys: RunsViewKeyMap,
+		},
+		diffViewState: diffViewState{
+			keys: DiffViewKeyMap,
+		},
 		parentWorkflowMode: false,
 		confirmationFlowState: confirmationFlowStateMsg{
 			state:                         NO_FLOW_RUNNING,
@@ -850,7 +970,13 @@ func initialModel() model {
 		searchInput:        textInput,
 		ready:              false,
 		workflows:          []*workflowTableListItem{},
-		selected:           make(map[int]bool),
+		selected:           make(map[string]*workflow.WorkflowExecutionInfo),
+		listColumns:        defaultListColumns,
+		listSort:           listSortState{unsupportedFields: map[string]bool{}},
+		pageSize:           DEFAULT_TABLE_LIST_PAGE_SIZE,
+		pageInput:          newPageInput(),
+		// Init schedules the first tick
+		relativeTimeTicking: true,
 		upToDateWorkflowCount: map[temporalEnums.WorkflowExecutionStatus]int64{
 			temporalEnums.WORKFLOW_EXECUTION_STATUS_COMPLETED: 0,
 			temporalEnums.WORKFLOW_EXECUTION_STATUS_RUNNING:   0,
@@ -864,6 +990,12 @@ func (m model) View() string {
 	if len(m.focusedWorkflowState.compactedHistoryStack) > 0 {
 		return m.focusedModeView()
 	}
+	if m.diffViewState.active {
+		return m.renderDiffView()
+	}
+	if m.runsViewState.workflowId != "" {
+		return m.renderRunsView()
+	}
 	view := m.renderHeader() + "\n" + m.renderTable(m.workflows) + "\n" + m.renderFooter()
 	return view
 }
@@ -885,8 +1017,78 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case setFocusedWorkflowMsg:
-		m.focusedWorkflowState.cursor = 0
-		m.focusedWorkflowState.compactedHistoryStack = append(m.focusedWorkflowState.compactedHistoryStack, msg.compactedHistoryStackItem)
+		m.focusedWorkflowState.resetCursors()
+		// The other views are built per workflow, start the new workflow in the compact view
+		m.focusedWorkflowState.viewMode = COMPACT_VIEW
+		m.focusedWorkflowState.following = false
+		m.focusedWorkflowState.historySearch = newHistorySearchState()
+		m.focusedWorkflowState.historyLoadSession++
+		stackItem := msg.compactedHistoryStackItem
+		stackItem.historyLoadSession = m.focusedWorkflowState.historyLoadSession
+		m.focusedWorkflowState.compactedHistoryStack = append(m.focusedWorkflowState.compactedHistoryStack, stackItem)
+		if stackItem.isHistoryLoading() {
+			return m, m.loadHistoryPageCmd(stackItem.historyLoadSession, stackItem.workflowId, stackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId(), stackItem.historyNextPageToken)
+		}
+		return m, nil
+	case historyPageMsg:
+		return m.handleHistoryPageMsg(msg)
+
+	case followWorkflowMsg:
+		return m.handleFollowWorkflowMsg(msg)
+	case followHighlightExpiredMsg:
+		if msg.changedAt.Equal(m.focusedWorkflowState.changedAt) {
+			m.focusedWorkflowState.changedEventIds = nil
+		}
+		return m, nil
+
+	case runsLoadedMsg:
+		if msg.workflowId != m.runsViewState.workflowId {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.runsViewState.workflowId = ""
+			tickCmd := m.resumeRelativeTimeTickCmd()
+			return m, tea.Batch(statusMessageCmd(fmt.Sprintf("Failed to load runs: %v", msg.err)), tickCmd)
+		}
+		m.runsViewState.loading = false
+		m.runsViewState.runs = msg.runs
+		return m, nil
+
+	case diffLoadedMsg:
+		if !m.diffViewState.active || msg.session != m.diffViewState.session {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.diffViewState.active = false
+			tickCmd := m.resumeRelativeTimeTickCmd()
+			return m, tea.Batch(statusMessageCmd(fmt.Sprintf("Failed to load histories: %v", msg.err)), tickCmd)
+		}
+		m.diffViewState.loading = false
+		m.diffViewState.rows = msg.rows
+		m.diffViewState.truncated = msg.truncated
+		return m, nil
+
+	case pendingActivitiesTickMsg:
+		return m.handlePendingActivitiesTickMsg(msg)
+	case pendingActivitiesRefreshedMsg:
+		return m.handlePendingActivitiesRefreshedMsg(msg)
+
+	case childWorkflowTreeMsg:
+		if msg.err != nil {
+			// Only leave the tree view if it is still showing the tree that failed to load
+			if len(m.focusedWorkflowState.compactedHistoryStack) > 0 && m.focusedWorkflowState.viewMode == TREE_VIEW {
+				currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+				if currentHistoryStackItem.workflowId == msg.workflowId && currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId() == msg.runId {
+					m.focusedWorkflowState.viewMode = COMPACT_VIEW
+				}
+			}
+			return m, statusMessageCmd(fmt.Sprintf("Failed to load child workflows: %v", msg.err))
+		}
+		for i, stackItem := range m.focusedWorkflowState.compactedHistoryStack {
+			if stackItem.workflowId == msg.workflowId && stackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId() == msg.runId {
+				m.focusedWorkflowState.compactedHistoryStack[i].childWorkflowTree = msg.tree
+			}
+		}
 		return m, nil
 
 	case confirmationFlowStateMsg:
@@ -897,11 +1099,37 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case ACTION_COMPLETED:
 			m.confirmationFlowState = msg
+			if msg.onCompletedCmd != nil {
+				return m, tea.Batch(m.clearCompletionCmd(), msg.onCompletedCmd)
+			}
 			m.clearListState()
 			return m, m.clearCompletionCmd()
 		}
 		return m, nil
 
+	case statusMessageMsg:
+		m.statusMessage = msg.message
+		return m, clearStatusMessageCmd(msg.message)
+	case clearStatusMessageMsg:
+		// Only clear the message if it has not been replaced by a newer one
+		if m.statusMessage == msg.message {
+			m.statusMessage = ""
+		}
+		return m, nil
+
+	case jumpToPageMsg:
+		return m.handleJumpToPageMsg(msg)
+	case listColumnsEditedMsg:
+		return m.handleListColumnsEditedMsg(msg)
+	case activityOptionsEditedMsg:
+		return m.handleActivityOptionsEditedMsg(msg)
+	case editorClosedMsg:
+		os.Remove(msg.path)
+		if msg.err != nil {
+			return m, statusMessageCmd(fmt.Sprintf("Editor exited with error: %v", msg.err))
+		}
+		return m, nil
+
 	case updateWorkflowCountMsg:
 		m.upToDateWorkflowCount[msg.executionStatus] = msg.count
 		return m, nil
@@ -915,6 +1143,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if _, ok := msg.updateMapping[workflowId]; ok {
 				m.workflows[i].attempts = msg.updateMapping[workflowId]
 			}
+			if workflowTaskAttempts, ok := msg.workflowTaskAttemptMapping[workflowId]; ok {
+				m.workflows[i].workflowTaskAttempts = workflowTaskAttempts
+			}
 		}
 		return m, m.updateVisibleWorkflowAttempsBackgroundCmd(10)
 
@@ -926,14 +1157,36 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, m.updateVisibleWorkflowsBackgroundCmd()
+	case updateVisibleWorkflowLastEventsMsg:
+		for _, existingWorkflow := range m.workflows {
+			if lastEventTime, ok := msg.lastEventTimes[existingWorkflow.workflow.GetExecution().GetRunId()]; ok {
+				existingWorkflow.lastEventTime = lastEventTime
+			}
+		}
+		return m, m.updateVisibleWorkflowLastEventsBackgroundCmd(10)
+	case relativeTimeTickMsg:
+		return m.handleRelativeTimeTickMsg()
 	case retrievedSearchOptionsMsg:
 		m.searchInput.SetSuggestions(msg.searchOptions)
 		m.searchOptions = msg.searchOptions
 		return m, nil
 
 	case updateWorkflowsMsg:
+		// Keep the last event times until they are fetched again
+		lastEventTimes := map[string]time.Time{}
+		for _, existingWorkflow := range m.workflows {
+			lastEventTimes[existingWorkflow.workflow.GetExecution().GetRunId()] = existingWorkflow.lastEventTime
+		}
+		for _, updatedWorkflow := range msg.workflows {
+			updatedWorkflow.lastEventTime = lastEventTimes[updatedWorkflow.workflow.GetExecution().GetRunId()]
+		}
 		m.workflows = msg.workflows
-		m.nextPageTokenCache[m.page+1] = msg.nextPageToken
+		m.nextPageTokenCache[msg.page+1] = msg.nextPageToken
+		m.totalWorkflowCount = msg.totalCount
+		if msg.unsupportedSortField != "" {
+			m.listSort.unsupportedFields[msg.unsupportedSortField] = true
+		}
+		m.listSort.sortWithinPage(m.workflows)
 		return m, nil
 
 	// Is it a key press?
@@ -943,9 +1196,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.confirmationFlowState.state = EXECUTING_ACTION
 				// Wrap the command to set the state to action completed
 				wrappedFunc := func() tea.Msg {
-					m.confirmationFlowState.commandThatRunsOnConfirmation()
+					result := m.confirmationFlowState.commandThatRunsOnConfirmation()
 					m.confirmationFlowState.state = ACTION_COMPLETED
-					m.clearListState()
+					if failed, ok := result.(confirmationActionFailedMsg); ok {
+						m.confirmationFlowState.executionSuccessMessage = failed.message
+					}
+					if m.confirmationFlowState.onCompletedCmd == nil {
+						m.clearListState()
+					}
 					return m.confirmationFlowState
 				}
 				return m, wrappedFunc
@@ -958,6 +1216,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.searchInput.Focused() {
 			return m.handleSearchUpdate(msg)
 		}
+		if m.pageInput.Focused() {
+			return m.updatePageInput(msg)
+		}
+		// Focused mode has its own keymap, the list keys should not leak into it
+		if len(m.focusedWorkflowState.compactedHistoryStack) > 0 {
+			return m.UpdateFocusedModeState(msg)
+		}
+		if m.diffViewState.active {
+			return m.updateDiffView(msg)
+		}
+		if m.runsViewState.workflowId != "" {
+			return m.updateRunsView(msg)
+		}
 
 		m = m.handleSearchModeSelect(msg)
 
@@ -967,6 +1238,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case key.Matches(msg, m.keys.ToggleParentWorkflowMode):
 			m.parentWorkflowMode = !m.parentWorkflowMode
+			m.clearListState()
 			return m, m.refetchWorkflowsCmd()
 		case key.Matches(msg, m.keys.RestartWorkflow):
 			if m.cursor < len(m.workflows) {
@@ -990,25 +1262,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Help):
 			m.help.ShowAll = !m.help.ShowAll
 		case key.Matches(msg, m.keys.NextPage):
-			m.page++
-			return m, m.refetchWorkflowsCmd()
+			return m.nextPage()
 		case key.Matches(msg, m.keys.PrevPage):
-			if m.page > 0 {
-				m.page--
-			}
-			return m, m.refetchWorkflowsCmd()
+			return m.previousPage()
+		case key.Matches(msg, m.keys.GoToPage):
+			return m, m.pageInput.Focus()
 		// Reset the search params if c is pressed
 		case key.Matches(msg, m.keys.ClearSearch):
 			m.activeSearchParams = make(map[searchMode][]string)
-			m.cursor = 0
-			m.page = 0
+			// The page tokens belong to the previous query
+			m.clearListState()
 			return m, m.refetchWorkflowsCmd()
 		case key.Matches(msg, m.keys.RefetchWorkflows):
 			return m, m.refetchWorkflowsCmd()
-			// The "enter" key and the spacebar (a literal space) toggle
-			// the selected state for the item that the cursor is pointing at.
-		case len(m.focusedWorkflowState.compactedHistoryStack) > 0:
-			return m.UpdateFocusedModeState(msg)
 
 		case key.Matches(msg, m.keys.FocusWorkflow):
 			if m.cursor < len(m.workflows) {
@@ -1026,9 +1292,45 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor < len(m.workflows)-1 {
 				m.cursor++
 			}
+		// The "enter" key and the spacebar (a literal space) toggle
+		// the selected state for the item that the cursor is pointing at.
 		case key.Matches(msg, m.keys.Select):
 			if m.cursor < len(m.workflows) {
-				m.selected[m.cursor] = true
+				execution := m.workflows[m.cursor].workflow
+				runId := execution.GetExecution().GetRunId()
+				if m.selected[runId] != nil {
+					delete(m.selected, runId)
+				} else {
+					m.selected[runId] = execution
+				}
+			}
+		case key.Matches(msg, m.keys.DiffWorkflows):
+			return m.openDiffView()
+		case key.Matches(msg, m.keys.EditColumns):
+			return m, m.editListColumnsCmd()
+		case key.Matches(msg, m.keys.SortByNextColumn):
+			return m.cycleListSortColumn()
+		case key.Matches(msg, m.keys.ReverseSort):
+			return m.reverseListSort()
+		case key.Matches(msg, m.keys.ShowRuns):
+			if m.cursor < len(m.workflows) {
+				return m.openRunsView(m.workflows[m.cursor].workflow.GetExecution().GetWorkflowId())
+			}
+		case key.Matches(msg, m.keys.YankWorkflowId):
+			if m.cursor < len(m.workflows) {
+				return m, yankCmd("workflow id", m.workflows[m.cursor].workflow.GetExecution().GetWorkflowId())
+			}
+		case key.Matches(msg, m.keys.YankRunId):
+			if m.cursor < len(m.workflows) {
+				return m, yankCmd("run id", m.workflows[m.cursor].workflow.GetExecution().GetRunId())
+			}
+		case key.Matches(msg, m.keys.YankRowJson):
+			if m.cursor < len(m.workflows) {
+				rowJson, err := protoToPrettyJSON(m.workflows[m.cursor].workflow)
+				if err != nil {
+					return m, statusMessageCmd(fmt.Sprintf("Failed to convert row to json: %v", err))
+				}
+				return m, yankCmd("row json", rowJson)
 			}
 		}
 	}
@@ -1039,6 +1341,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) Init() tea.Cmd {
+	if m.offline {
+		return nil
+	}
 	return tea.Sequence(
 		m.refetchWorkflowsCmd(),
 		tea.Batch(
@@ -1052,11 +1357,28 @@ func (m model) Init() tea.Cmd {
 			m.refetchWorkflowCountCmd(temporalEnums.WORKFLOW_EXECUTION_STATUS_TERMINATED),
 			m.refetchWorkflowCountCmd(temporalEnums.WORKFLOW_EXECUTION_STATUS_RUNNING),
 			m.updateVisibleWorkflowAttempsBackgroundCmd(3),
+			m.updateVisibleWorkflowLastEventsBackgroundCmd(1),
+			relativeTimeTickCmd(),
 		),
 	)
 }
 func main() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	parseFlags()
+	if flag.NArg() > 0 {
+		if err := runCommand(flag.Args()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	m := initialModel()
+	listColumns, err := parseListColumns(m.getTemporalConfig().Columns)
+	if err != nil {
+		log.Fatalf("Invalid columns in config: %v", err)
+	}
+	m.listColumns = listColumns
+	m.pageSize = getListPageSize(*pageSizeFlag, m.getTemporalConfig().PageSize)
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/history/v1"
+)
+
+// ========================================
+// Markers
+// ========================================
+
+// Marker names and detail keys written by the Go SDK and by the core based SDKs (TypeScript, Python, .NET)
+const (
+	LOCAL_ACTIVITY_MARKER      = "LocalActivity"
+	CORE_LOCAL_ACTIVITY_MARKER = "core_local_activity"
+	SIDE_EFFECT_MARKER         = "SideEffect"
+	MUTABLE_SIDE_EFFECT_MARKER = "MutableSideEffect"
+	VERSION_MARKER             = "Version"
+	CORE_PATCH_MARKER          = "core_patch"
+
+	MARKER_DATA_KEY           = "data"
+	MARKER_RESULT_KEY         = "result"
+	MARKER_SIDE_EFFECT_ID_KEY = "side-effect-id"
+	MARKER_CHANGE_ID_KEY      = "change-id"
+	MARKER_VERSION_KEY        = "version"
+	MARKER_PATCH_DATA_KEY     = "patch_data"
+)
+
+type decodedMarker struct {
+	actionType    string
+	icon          string
+	rowContent    string
+	eventsContent []eventContent
+}
+
+// Decodes the first payload of a marker detail into value, returns false when the detail is missing or not json
+func decodeMarkerDetail(details map[string]*common.Payloads, key string, value interface{}) bool {
+	payloads := details[key].GetPayloads()
+	if len(payloads) == 0 {
+		return false
+	}
+	return json.Unmarshal(payloads[0].GetData(), value) == nil
+}
+
+// Returns the first field of the marker data that is set, the SDKs use different names for the same field
+func getMarkerDataField(data map[string]interface{}, names ...string) string {
+	for _, name := range names {
+		if value, ok := data[name]; ok && value != nil {
+			return fmt.Sprint(value)
+		}
+	}
+	return ""
+}
+
+func decodeLocalActivityMarker(details map[string]*common.Payloads) decodedMarker {
+	marker := decodedMarker{actionType: "Local Activity", icon: "🏠"}
+	data := map[string]interface{}{}
+	decodeMarkerDetail(details, MARKER_DATA_KEY, &data)
+	marker.rowContent = getMarkerDataField(data, "ActivityType", "activity_type")
+	if attempt := getMarkerDataField(data, "Attempt", "attempt"); attempt != "" && attempt != "0" && attempt != "1" {
+		marker.rowContent += " 🔄" + attempt
+	}
+	if activityId := getMarkerDataField(data, "ActivityID", "activity_id"); activityId != "" {
+		marker.eventsContent = append(marker.eventsContent, eventContent{eventType: "Activity Id", eventData: activityId})
+	}
+	// Failed local activities have no result, their failure is on the marker itself
+	if len(details[MARKER_RESULT_KEY].GetPayloads()) > 0 {
+		marker.eventsContent = append(marker.eventsContent, eventContent{eventType: "Output", eventData: formatPayloads(details[MARKER_RESULT_KEY])})
+	}
+	return marker
+}
+
+func decodeSideEffectMarker(markerName string, details map[string]*common.Payloads) decodedMarker {
+	marker := decodedMarker{actionType: "Side Effect", icon: "🎲"}
+	if markerName == MUTABLE_SIDE_EFFECT_MARKER {
+		marker.actionType = "Mutable Side Effect"
+		marker.icon = "🔀"
+	}
+	// The side effect id is a number for side effects and a string for mutable side effects
+	var sideEffectId interface{}
+	if decodeMarkerDetail(details, MARKER_SIDE_EFFECT_ID_KEY, &sideEffectId) {
+		marker.rowContent = fmt.Sprint(sideEffectId)
+	}
+	if len(details[MARKER_DATA_KEY].GetPayloads()) > 0 {
+		marker.eventsContent = append(marker.eventsContent, eventContent{eventType: "Output", eventData: formatPayloads(details[MARKER_DATA_KEY])})
+	}
+	return marker
+}
+
+func decodeVersionMarker(details map[string]*common.Payloads) decodedMarker {
+	marker := decodedMarker{actionType: "Version", icon: "🔖"}
+	var changeId string
+	var version int
+	decodeMarkerDetail(details, MARKER_CHANGE_ID_KEY, &changeId)
+	marker.rowContent = changeId
+	if decodeMarkerDetail(details, MARKER_VERSION_KEY, &version) {
+		marker.rowContent += " v" + strconv.Itoa(version)
+	}
+	marker.eventsContent = append(marker.eventsContent, eventContent{eventType: "Change Id", eventData: changeId})
+	return marker
+}
+
+func decodePatchMarker(details map[string]*common.Payloads) decodedMarker {
+	marker := decodedMarker{actionType: "Patch", icon: "🔖"}
+	data := map[string]interface{}{}
+	decodeMarkerDetail(details, MARKER_PATCH_DATA_KEY, &data)
+	marker.rowContent = getMarkerDataField(data, "id")
+	if getMarkerDataField(data, "deprecated") == "true" {
+		marker.rowContent += " (deprecated)"
+	}
+	marker.eventsContent = append(marker.eventsContent, eventContent{eventType: "Change Id", eventData: getMarkerDataField(data, "id")})
+	return marker
+}
+
+func getSortedMarkerDetailKeys(details map[string]*common.Payloads) []string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Turns the markers the SDKs record for local activities, side effects and versions into named rows
+func decodeMarker(attributes *history.MarkerRecordedEventAttributes) decodedMarker {
+	details := attributes.GetDetails()
+	var marker decodedMarker
+	switch attributes.GetMarkerName() {
+	case LOCAL_ACTIVITY_MARKER, CORE_LOCAL_ACTIVITY_MARKER:
+		marker = decodeLocalActivityMarker(details)
+	case SIDE_EFFECT_MARKER, MUTABLE_SIDE_EFFECT_MARKER:
+		marker = decodeSideEffectMarker(attributes.GetMarkerName(), details)
+	case VERSION_MARKER:
+		marker = decodeVersionMarker(details)
+	case CORE_PATCH_MARKER:
+		marker = decodePatchMarker(details)
+	default:
+		marker = decodedMarker{actionType: "Marker", icon: "📍", rowContent: attributes.GetMarkerName()}
+		for _, key := range getSortedMarkerDetailKeys(details) {
+			marker.eventsContent = append(marker.eventsContent, eventContent{eventType: key, eventData: formatPayloads(details[key])})
+		}
+	}
+	if attributes.GetFailure() != nil {
+		marker.icon = "❌"
+		marker.eventsContent = append(marker.eventsContent, eventContent{eventType: "Error", eventData: formatFailure(attributes.GetFailure())})
+	}
+	return marker
+}
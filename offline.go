@@ -0,0 +1,155 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"go.temporal.io/api/common/v1"
+	temporalEnums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/temporalproto"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// ========================================
+// Offline Mode
+// ========================================
+
+// Status of a closed workflow by its last event, workflows without a close event are running
+var closeEventTypeToStatus = map[temporalEnums.EventType]temporalEnums.WorkflowExecutionStatus{
+	temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:        temporalEnums.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+	temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:           temporalEnums.WORKFLOW_EXECUTION_STATUS_FAILED,
+	temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:        temporalEnums.WORKFLOW_EXECUTION_STATUS_TIMED_OUT,
+	temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:         temporalEnums.WORKFLOW_EXECUTION_STATUS_CANCELED,
+	temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:       temporalEnums.WORKFLOW_EXECUTION_STATUS_TERMINATED,
+	temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW: temporalEnums.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW,
+}
+
+// Reads a json file written by export-history or `temporal workflow show --output json`
+func readProtoJSONFile(path string, message proto.Message) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	// Files from newer servers can contain fields this version does not know
+	if err := (temporalproto.CustomJSONUnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, message); err != nil {
+		return fmt.Errorf("invalid json in %s: %w", path, err)
+	}
+	return nil
+}
+
+// Builds the parts of the describe response that can be read from the history, used when there is no describe snapshot.
+// The history does not record its own run id (OriginalExecutionRunId is the first run of a reset chain), so it is left empty
+func describeFromHistory(historyList []*history.HistoryEvent, workflowId string) *workflowservice.DescribeWorkflowExecutionResponse {
+	startedEventAttributes := getWorkflowStartedEventAttributes(historyList)
+	if startedEventAttributes.GetWorkflowId() != "" {
+		workflowId = startedEventAttributes.GetWorkflowId()
+	}
+	info := &workflow.WorkflowExecutionInfo{
+		Execution:        &common.WorkflowExecution{WorkflowId: workflowId},
+		Type:             startedEventAttributes.GetWorkflowType(),
+		TaskQueue:        startedEventAttributes.GetTaskQueue().GetName(),
+		Status:           temporalEnums.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		HistoryLength:    int64(len(historyList)),
+		Memo:             startedEventAttributes.GetMemo(),
+		SearchAttributes: startedEventAttributes.GetSearchAttributes(),
+		ParentExecution:  startedEventAttributes.GetParentWorkflowExecution(),
+		RootExecution:    startedEventAttributes.GetRootWorkflowExecution(),
+	}
+	if len(historyList) > 0 {
+		info.StartTime = historyList[0].GetEventTime()
+		lastEvent := historyList[len(historyList)-1]
+		if status, ok := closeEventTypeToStatus[lastEvent.GetEventType()]; ok {
+			info.Status = status
+			info.CloseTime = lastEvent.GetEventTime()
+		}
+	}
+	return &workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: info,
+		ExecutionConfig: &workflow.WorkflowExecutionConfig{
+			TaskQueue:                  startedEventAttributes.GetTaskQueue(),
+			WorkflowExecutionTimeout:   startedEventAttributes.GetWorkflowExecutionTimeout(),
+			WorkflowRunTimeout:         startedEventAttributes.GetWorkflowRunTimeout(),
+			DefaultWorkflowTaskTimeout: startedEventAttributes.GetWorkflowTaskTimeout(),
+		},
+	}
+}
+
+// Disables the keys that need a server, disabled keys do not match and are hidden from the help
+func getOfflineFocusedModeKeyMap() FocusedKeyMap {
+	keys := FocusedModeKeyMap
+	for _, binding := range []*key.Binding{
+		&keys.FocusChildWorkflow,
+		&keys.ToggleChildTree,
+		&keys.TogglePendingActivities,
+		&keys.TogglePauseActivity,
+		&keys.ResetActivity,
+		&keys.EditActivityOptions,
+		&keys.FocusParent,
+		&keys.FocusPreviousRun,
+		&keys.FocusNextRun,
+		&keys.FocusFirstRun,
+		&keys.ToggleFollow,
+	} {
+		binding.SetEnabled(false)
+	}
+	return keys
+}
+
+func loadOfflineStackItem(historyPath string, describePath string) (compactHistoryStackItem, error) {
+	historyFile := &history.History{}
+	if err := readProtoJSONFile(historyPath, historyFile); err != nil {
+		return compactHistoryStackItem{}, err
+	}
+	historyEvents := historyFile.GetEvents()
+	// Without a workflow id in the history the file name is the closest thing to one
+	workflowDescription := describeFromHistory(historyEvents, strings.TrimSuffix(filepath.Base(historyPath), filepath.Ext(historyPath)))
+	if describePath != "" {
+		workflowDescription = &workflowservice.DescribeWorkflowExecutionResponse{}
+		if err := readProtoJSONFile(describePath, workflowDescription); err != nil {
+			return compactHistoryStackItem{}, err
+		}
+	}
+	execution := workflowDescription.GetWorkflowExecutionInfo().GetExecution()
+	return compactHistoryStackItem{
+		workflowId:          execution.GetWorkflowId(),
+		runId:               execution.GetRunId(),
+		history:             historyEvents,
+		compactHistory:      createCompactHistory(historyEvents, workflowDescription.GetPendingActivities()),
+		workflowDescription: workflowDescription,
+		workflowTaskProblem: getWorkflowTaskProblem(historyEvents, workflowDescription),
+	}, nil
+}
+
+// kairos-cli view [-describe describe.json] <history.json>
+func viewCommand(args []string) error {
+	flags := flag.NewFlagSet("view", flag.ExitOnError)
+	describePath := flags.String("describe", "", "Describe response snapshot (e.g. from `temporal workflow describe --output json --raw`)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kairos-cli view [-describe describe.json] <history.json>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected a history file")
+	}
+	stackItem, err := loadOfflineStackItem(flags.Arg(0), *describePath)
+	if err != nil {
+		return err
+	}
+	m := initialModel()
+	m.offline = true
+	m.focusedWorkflowState.keys = getOfflineFocusedModeKeyMap()
+	m.focusedWorkflowState.compactedHistoryStack = []compactHistoryStackItem{stackItem}
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err = p.Run()
+	return err
+}
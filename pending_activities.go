@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ========================================
+// Pending Activities
+// ========================================
+
+// The countdowns are redrawn every tick, the pending activities are reloaded every refresh interval
+const (
+	PENDING_ACTIVITIES_TICK_INTERVAL    = time.Second
+	PENDING_ACTIVITIES_REFRESH_INTERVAL = time.Second * 5
+)
+
+var pendingActivitiesBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+var pendingActivityLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
+var pendingActivityPausedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffaf00")).Bold(true)
+
+type pendingActivitiesTickMsg struct {
+	// Ticks of a previous time the panel was opened are ignored
+	session int
+}
+
+type pendingActivitiesRefreshedMsg struct {
+	workflowId  string
+	runId       string
+	description *workflowservice.DescribeWorkflowExecutionResponse
+	err         error
+}
+
+func pendingActivitiesTickCmd(session int) tea.Cmd {
+	return tea.Tick(PENDING_ACTIVITIES_TICK_INTERVAL, func(_ time.Time) tea.Msg {
+		return pendingActivitiesTickMsg{session: session}
+	})
+}
+
+func (m *model) refreshPendingActivitiesCmd(workflowId string, runId string) tea.Cmd {
+	return func() tea.Msg {
+		temporalClient, _ := m.getTemporalClient()
+		description, err := temporalClient.DescribeWorkflowExecution(context.Background(), workflowId, runId)
+		return pendingActivitiesRefreshedMsg{workflowId: workflowId, runId: runId, description: description, err: err}
+	}
+}
+
+func (m *model) startPendingActivitiesTicksCmd() tea.Cmd {
+	m.focusedWorkflowState.pendingActivitiesSession++
+	m.focusedWorkflowState.pendingActivitiesRefreshedAt = time.Now()
+	return pendingActivitiesTickCmd(m.focusedWorkflowState.pendingActivitiesSession)
+}
+
+func (m model) handlePendingActivitiesTickMsg(msg pendingActivitiesTickMsg) (tea.Model, tea.Cmd) {
+	if msg.session != m.focusedWorkflowState.pendingActivitiesSession || len(m.focusedWorkflowState.compactedHistoryStack) == 0 || m.focusedWorkflowState.viewMode != PENDING_ACTIVITIES_VIEW {
+		return m, nil
+	}
+	cmds := []tea.Cmd{pendingActivitiesTickCmd(msg.session)}
+	if time.Since(m.focusedWorkflowState.pendingActivitiesRefreshedAt) >= PENDING_ACTIVITIES_REFRESH_INTERVAL {
+		m.focusedWorkflowState.pendingActivitiesRefreshedAt = time.Now()
+		currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+		runId := currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId()
+		cmds = append(cmds, m.refreshPendingActivitiesCmd(currentHistoryStackItem.workflowId, runId))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) handlePendingActivitiesRefreshedMsg(msg pendingActivitiesRefreshedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, statusMessageCmd(fmt.Sprintf("Failed to refresh pending activities: %v", msg.err))
+	}
+	// The focused mode may have been left while the refresh was in flight
+	if len(m.focusedWorkflowState.compactedHistoryStack) == 0 {
+		return m, nil
+	}
+	for i, stackItem := range m.focusedWorkflowState.compactedHistoryStack {
+		if stackItem.workflowId == msg.workflowId && stackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId() == msg.runId {
+			stackItem := &m.focusedWorkflowState.compactedHistoryStack[i]
+			stackItem.workflowDescription = msg.description
+			stackItem.compactHistory.applyPendingActivities(msg.description.GetPendingActivities())
+			stackItem.workflowTaskProblem = getWorkflowTaskProblem(stackItem.history, msg.description)
+		}
+	}
+	// The cursor of the other views points at history rows
+	if m.focusedWorkflowState.viewMode != PENDING_ACTIVITIES_VIEW {
+		return m, nil
+	}
+	pendingActivities := m.focusedWorkflowState.getCurrentHistoryStackItem().workflowDescription.GetPendingActivities()
+	if m.focusedWorkflowState.cursor >= len(pendingActivities) {
+		m.focusedWorkflowState.cursor = max(0, len(pendingActivities)-1)
+	}
+	return m, nil
+}
+
+func (m *focusedModeState) getSelectedPendingActivity() *workflow.PendingActivityInfo {
+	pendingActivities := m.getCurrentHistoryStackItem().workflowDescription.GetPendingActivities()
+	if m.cursor >= len(pendingActivities) {
+		return nil
+	}
+	return pendingActivities[m.cursor]
+}
+
+// Handles the keys of the pending activities view, returns false for keys that are handled by the focused mode
+func (m *model) updatePendingActivities(msg tea.KeyMsg) (bool, tea.Cmd) {
+	keys := m.focusedWorkflowState.keys
+	pendingActivities := m.focusedWorkflowState.getCurrentHistoryStackItem().workflowDescription.GetPendingActivities()
+	switch {
+	case key.Matches(msg, keys.Up):
+		if m.focusedWorkflowState.cursor > 0 {
+			m.focusedWorkflowState.cursor--
+		}
+		return true, nil
+	case key.Matches(msg, keys.Down):
+		if m.focusedWorkflowState.cursor < len(pendingActivities)-1 {
+			m.focusedWorkflowState.cursor++
+		}
+		return true, nil
+	case key.Matches(msg, keys.YankRowJson):
+		selectedActivity := m.focusedWorkflowState.getSelectedPendingActivity()
+		if selectedActivity == nil {
+			return true, nil
+		}
+		activityJson, err := protoToPrettyJSON(selectedActivity)
+		if err != nil {
+			return true, statusMessageCmd(fmt.Sprintf("Failed to convert activity to json: %v", err))
+		}
+		return true, yankCmd("pending activity json", activityJson)
+	case key.Matches(msg, keys.TogglePauseActivity), key.Matches(msg, keys.ResetActivity), key.Matches(msg, keys.EditActivityOptions):
+		selectedActivity := m.focusedWorkflowState.getSelectedPendingActivity()
+		if selectedActivity == nil {
+			return true, statusMessageCmd("No pending activity selected")
+		}
+		currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+		runId := currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId()
+		switch {
+		case key.Matches(msg, keys.TogglePauseActivity):
+			return true, m.togglePauseActivityCmd(currentHistoryStackItem.workflowId, runId, selectedActivity)
+		case key.Matches(msg, keys.ResetActivity):
+			return true, m.resetActivityCmd(currentHistoryStackItem.workflowId, runId, selectedActivity)
+		}
+		if currentHistoryStackItem.isHistoryPartial() {
+			return true, statusMessageCmd(PARTIAL_HISTORY_MESSAGE)
+		}
+		return true, m.editActivityOptionsCmd(currentHistoryStackItem.workflowId, runId, currentHistoryStackItem.history, selectedActivity)
+	case keys.isHistoryRowKey(msg):
+		return true, nil
+	}
+	return false, nil
+}
+
+// Formats a point in time relative to now, e.g. "in 12s" or "3 min ago"
+func formatPendingActivityTime(t time.Time) string {
+	if t.After(time.Now()) {
+		return "in " + time.Until(t).Round(time.Second).String()
+	}
+	return getRelativeTimeDiff(time.Now(), t)
+}
+
+func formatPendingActivityAttempts(pendingActivity *workflow.PendingActivityInfo) string {
+	if pendingActivity.GetMaximumAttempts() == 0 {
+		return fmt.Sprintf("%d/∞", pendingActivity.GetAttempt())
+	}
+	return fmt.Sprintf("%d/%d", pendingActivity.GetAttempt(), pendingActivity.GetMaximumAttempts())
+}
+
+func renderPendingActivityField(label string, value string) string {
+	return pendingActivityLabelStyle.Render(label+": ") + value
+}
+
+func renderPendingActivity(pendingActivity *workflow.PendingActivityInfo, width int) []string {
+	state := pendingActivity.GetState().String()
+	if pendingActivity.GetPaused() {
+		state += " " + pendingActivityPausedStyle.Render("PAUSED")
+	}
+	header := pendingActivity.GetActivityType().GetName() + pendingActivityLabelStyle.Render(" (id "+pendingActivity.GetActivityId()+")")
+	fields := []string{
+		renderPendingActivityField("State", state),
+		renderPendingActivityField("Attempt", formatPendingActivityAttempts(pendingActivity)),
+	}
+	if nextAttempt := pendingActivity.GetNextAttemptScheduleTime(); nextAttempt != nil {
+		fields = append(fields, renderPendingActivityField("Next attempt", nextAttempt.AsTime().In(time.Local).Format(time.RFC3339)+" ("+formatPendingActivityTime(nextAttempt.AsTime())+")"))
+	}
+	if retryInterval := pendingActivity.GetCurrentRetryInterval(); retryInterval != nil {
+		fields = append(fields, renderPendingActivityField("Retry interval", retryInterval.AsDuration().String()))
+	}
+	if expiration := pendingActivity.GetExpirationTime(); expiration != nil {
+		fields = append(fields, renderPendingActivityField("Expires", formatPendingActivityTime(expiration.AsTime())))
+	}
+	lines := []string{header, "  " + strings.Join(fields, "  ")}
+
+	workerFields := []string{}
+	if lastStarted := pendingActivity.GetLastStartedTime(); lastStarted != nil {
+		workerFields = append(workerFields, renderPendingActivityField("Last started", formatPendingActivityTime(lastStarted.AsTime())))
+	}
+	if lastHeartbeat := pendingActivity.GetLastHeartbeatTime(); lastHeartbeat != nil {
+		workerFields = append(workerFields, renderPendingActivityField("Last heartbeat", formatPendingActivityTime(lastHeartbeat.AsTime())))
+	}
+	if pendingActivity.GetLastWorkerIdentity() != "" {
+		workerFields = append(workerFields, renderPendingActivityField("Worker", pendingActivity.GetLastWorkerIdentity()))
+	}
+	if len(workerFields) > 0 {
+		lines = append(lines, "  "+strings.Join(workerFields, "  "))
+	}
+	if len(pendingActivity.GetHeartbeatDetails().GetPayloads()) > 0 {
+		heartbeatDetails := strings.Join(strings.Fields(formatPayloads(pendingActivity.GetHeartbeatDetails())), " ")
+		lines = append(lines, "  "+renderPendingActivityField("Heartbeat details", heartbeatDetails))
+	}
+	if pendingActivity.GetLastFailure() != nil {
+		lines = append(lines, "  "+renderPendingActivityField("Last failure", pendingActivity.GetLastFailure().GetMessage()))
+	}
+	for i, line := range lines {
+		lines[i] = lipgloss.NewStyle().MaxWidth(width).Render(line)
+	}
+	return lines
+}
+
+func (m model) renderPendingActivities(width int, height int) string {
+	innerWidth := width - 2
+	pendingActivities := m.focusedWorkflowState.getCurrentHistoryStackItem().workflowDescription.GetPendingActivities()
+	if len(pendingActivities) == 0 {
+		return pendingActivitiesBoxStyle.Width(innerWidth).Height(height).Render("No pending activities")
+	}
+	blocks := make([][]string, len(pendingActivities))
+	for i, pendingActivity := range pendingActivities {
+		blocks[i] = renderPendingActivity(pendingActivity, innerWidth)
+		if i == m.focusedWorkflowState.cursor {
+			blocks[i][0] = SelectedRowStyle.Render(blocks[i][0])
+		}
+	}
+	// Skip the first activities until the selected one fits
+	offset := 0
+	for offset < m.focusedWorkflowState.cursor {
+		visibleLines := 0
+		for i := offset; i <= m.focusedWorkflowState.cursor; i++ {
+			visibleLines += len(blocks[i]) + 1
+		}
+		if visibleLines <= height {
+			break
+		}
+		offset++
+	}
+	lines := []string{}
+	for _, block := range blocks[offset:] {
+		lines = append(lines, block...)
+		lines = append(lines, "")
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return pendingActivitiesBoxStyle.Width(innerWidth).Height(height).Render(strings.Join(lines, "\n"))
+}
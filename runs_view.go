@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"go.temporal.io/api/common/v1"
+	temporalEnums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ========================================
+// Runs View
+// ========================================
+
+// Every run of a workflow id is loaded, up to this limit
+const RUNS_VIEW_MAX_RUNS = 100
+
+// Started events fetched at the same time when loading the runs
+const RUNS_VIEW_FETCH_CONCURRENCY = 8
+
+type RunsKeyMap struct {
+	Up            key.Binding
+	Down          key.Binding
+	FocusWorkflow key.Binding
+	YankRunId     key.Binding
+	Back          key.Binding
+	Exit          key.Binding
+}
+
+var RunsViewKeyMap = RunsKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("↑/k", "move up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓/j", "move down"),
+	),
+	FocusWorkflow: key.NewBinding(
+		key.WithKeys("f", "enter"),
+		key.WithHelp("f/enter", "focus run"),
+	),
+	YankRunId: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy run id"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back to list"),
+	),
+	Exit: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "exit"),
+	),
+}
+
+func (k RunsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.FocusWorkflow, k.YankRunId, k.Back, k.Exit}
+}
+
+func (k RunsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+type runListItem struct {
+	workflow *workflow.WorkflowExecutionInfo
+	// Why the run was started (e.g. retry, cron, continue-as-new)
+	startReason string
+}
+
+type runsViewState struct {
+	// Empty when the runs view is closed
+	workflowId string
+	loading    bool
+	runs       []*runListItem
+	cursor     int
+	keys       RunsKeyMap
+}
+
+type runsLoadedMsg struct {
+	workflowId string
+	runs       []*runListItem
+	err        error
+}
+
+func getRunStartReason(runId string, startedEventAttributes *history.WorkflowExecutionStartedEventAttributes) string {
+	if startedEventAttributes == nil {
+		return "--"
+	}
+	// A reset run keeps the run id of the run it was reset from as its original run
+	if startedEventAttributes.GetOriginalExecutionRunId() != "" && startedEventAttributes.GetOriginalExecutionRunId() != runId {
+		return "Reset"
+	}
+	switch startedEventAttributes.GetInitiator() {
+	case temporalEnums.CONTINUE_AS_NEW_INITIATOR_RETRY:
+		return "Retry"
+	case temporalEnums.CONTINUE_AS_NEW_INITIATOR_CRON_SCHEDULE:
+		return "Cron"
+	case temporalEnums.CONTINUE_AS_NEW_INITIATOR_WORKFLOW:
+		return "Continue-as-new"
+	}
+	if startedEventAttributes.GetParentWorkflowExecution() != nil {
+		return "Started by parent"
+	}
+	if startedEventAttributes.GetIdentity() != "" {
+		return "Started by " + startedEventAttributes.GetIdentity()
+	}
+	return "Started"
+}
+
+// The reason a run was started is only recorded in its first history event
+func (m *model) getStartedEventAttributes(workflowId string, runId string) *history.WorkflowExecutionStartedEventAttributes {
+	temporalClient, _ := m.getTemporalClient()
+	response, err := temporalClient.WorkflowService().GetWorkflowExecutionHistory(context.Background(), &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace:              m.getTemporalConfig().TemporalNamespace,
+		Execution:              &common.WorkflowExecution{WorkflowId: workflowId, RunId: runId},
+		MaximumPageSize:        1,
+		HistoryEventFilterType: temporalEnums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT,
+	})
+	if err != nil || len(response.GetHistory().GetEvents()) == 0 {
+		return nil
+	}
+	return response.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes()
+}
+
+func (m *model) loadRunsCmd(workflowId string) tea.Cmd {
+	return func() tea.Msg {
+		temporalClient, _ := m.getTemporalClient()
+		executions := []*workflow.WorkflowExecutionInfo{}
+		nextPageToken := []byte{}
+		for len(executions) < RUNS_VIEW_MAX_RUNS {
+			result, err := temporalClient.ListWorkflow(context.Background(), &workflowservice.ListWorkflowExecutionsRequest{
+				Query:         fmt.Sprintf("WorkflowId = '%s'", escapeQueryValue(workflowId)),
+				PageSize:      int32(RUNS_VIEW_MAX_RUNS - len(executions)),
+				NextPageToken: nextPageToken,
+			})
+			if err != nil {
+				return runsLoadedMsg{workflowId: workflowId, err: err}
+			}
+			executions = append(executions, result.GetExecutions()...)
+			nextPageToken = result.GetNextPageToken()
+			if len(nextPageToken) == 0 {
+				break
+			}
+		}
+		sort.Slice(executions, func(i, j int) bool {
+			return executions[i].GetStartTime().AsTime().After(executions[j].GetStartTime().AsTime())
+		})
+
+		runs := make([]*runListItem, len(executions))
+		var waitGroup sync.WaitGroup
+		semaphore := make(chan struct{}, RUNS_VIEW_FETCH_CONCURRENCY)
+		for i, execution := range executions {
+			semaphore <- struct{}{}
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				defer func() { <-semaphore }()
+				runId := execution.GetExecution().GetRunId()
+				runs[i] = &runListItem{
+					workflow:    execution,
+					startReason: getRunStartReason(runId, m.getStartedEventAttributes(workflowId, runId)),
+				}
+			}()
+		}
+		waitGroup.Wait()
+		return runsLoadedMsg{workflowId: workflowId, runs: runs}
+	}
+}
+
+func (m model) openRunsView(workflowId string) (model, tea.Cmd) {
+	m.runsViewState.workflowId = workflowId
+	m.runsViewState.loading = true
+	m.runsViewState.runs = []*runListItem{}
+	m.runsViewState.cursor = 0
+	return m, m.loadRunsCmd(workflowId)
+}
+
+func (m model) updateRunsView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := m.runsViewState.keys
+	switch {
+	case key.Matches(msg, keys.Exit):
+		return m, tea.Quit
+	case key.Matches(msg, keys.Back):
+		m.runsViewState.workflowId = ""
+		tickCmd := m.resumeRelativeTimeTickCmd()
+		return m, tickCmd
+	case key.Matches(msg, keys.Up):
+		if m.runsViewState.cursor > 0 {
+			m.runsViewState.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.runsViewState.cursor < len(m.runsViewState.runs)-1 {
+			m.runsViewState.cursor++
+		}
+	case key.Matches(msg, keys.FocusWorkflow):
+		if m.runsViewState.cursor < len(m.runsViewState.runs) {
+			execution := m.runsViewState.runs[m.runsViewState.cursor].workflow.GetExecution()
+			return m, m.setFocusedWorkflowCmd(execution.GetWorkflowId(), execution.GetRunId())
+		}
+	case key.Matches(msg, keys.YankRunId):
+		if m.runsViewState.cursor < len(m.runsViewState.runs) {
+			return m, yankCmd("run id", m.runsViewState.runs[m.runsViewState.cursor].workflow.GetExecution().GetRunId())
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderRunsView() string {
+	title := HeaderStyle.Render(fmt.Sprintf("Runs of %s (%d)", m.runsViewState.workflowId, len(m.runsViewState.runs)))
+	footer := m.help.ShortHelpView(m.runsViewState.keys.ShortHelp())
+	if m.statusMessage != "" {
+		footer = m.statusMessage
+	}
+	if m.runsViewState.loading {
+		return lipgloss.JoinVertical(lipgloss.Top, title, "Loading runs...", footer)
+	}
+	tableHeight := m.viewport.Height - lipgloss.Height(title) - lipgloss.Height(footer)
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderRight(false).
+		BorderLeft(false).
+		BorderTop(false).
+		BorderBottom(false).
+		BorderHeader(true).
+		BorderColumn(true).
+		Width(m.viewport.Width).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			switch {
+			case row == m.runsViewState.cursor:
+				return SelectedRowStyle
+			case row%2 == 0:
+				return EvenRowStyle
+			default:
+				return OddRowStyle
+			}
+		}).
+		Headers("Status", "Run Id", "Start Time", "Close Time", "Ended", "Started Because")
+	for _, run := range m.runsViewState.runs {
+		status := run.workflow.GetStatus().String()
+		closeTime := "--"
+		if run.workflow.GetStatus() != temporalEnums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			closeTime = run.workflow.GetCloseTime().AsTime().In(time.Local).Format(time.RFC3339)
+		}
+		startTimeDiff := getRelativeTimeDiff(time.Now(), run.workflow.GetStartTime().AsTime())
+		t.Row(statusToStyleMap[status].icon, run.workflow.GetExecution().GetRunId(), startTimeDiff, closeTime, statusToStyleMap[status].displayName, run.startReason)
+	}
+	tableView := lipgloss.NewStyle().Height(tableHeight).Render(t.Render())
+	return lipgloss.JoinVertical(lipgloss.Top, title, tableView, footer)
+}
@@ -16,6 +16,7 @@ import (
 	"sync"
 
 	"github.com/BurntSushi/toml"
+	temporalEnums "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/history/v1"
 	"go.temporal.io/sdk/client"
 	tlog "go.temporal.io/sdk/log"
@@ -27,6 +28,7 @@ var (
 	once           sync.Once
 	configOnce     sync.Once
 	isLocal        *bool
+	pageSizeFlag   *int
 )
 
 type NamespaceInfo struct {
@@ -34,6 +36,10 @@ type NamespaceInfo struct {
 	TemporalNamespace  string `toml:"temporal_namespace"`
 	TemporalPrivateKey string `toml:"temporal_private_key"`
 	TemporalPublicKey  string `toml:"temporal_public_key"`
+	// List columns, e.g. ["status", "type", "workflow_id 40", "search_attribute:CustomerId"]
+	Columns []string `toml:"columns"`
+	// Workflows per page of the list
+	PageSize int `toml:"page_size"`
 }
 
 type (
@@ -43,32 +49,47 @@ type (
 	}
 )
 
-func (m model) getTemporalConfig() NamespaceInfo {
+// Parses the global flags, the remaining arguments are the command (e.g. export-history)
+func parseFlags() {
 	configOnce.Do(func() {
 		isLocal = flag.Bool("local", false, "Connect to local temporal on localhost:7233")
+		pageSizeFlag = flag.Int("page-size", 0, "Workflows per page of the list")
 		namespace = *flag.String("namespace", "default", "Namespace")
 		if *isLocal {
 			namespace = "default"
 		}
 		flag.Parse()
 	})
+}
+
+func readTomlConfig() (TomlConfig, error) {
+	var config TomlConfig
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return config, err
+	}
+	_, err = toml.DecodeFile(filepath.Join(homeDir, ".config", "kairos", "credentials"), &config)
+	return config, err
+}
+
+func (m model) getTemporalConfig() NamespaceInfo {
+	parseFlags()
 	if *isLocal == true {
-		return NamespaceInfo{
+		localConfig := NamespaceInfo{
 			TemporalCloudHost:  "localhost:7233",
 			TemporalNamespace:  "default",
 			TemporalPrivateKey: "",
 			TemporalPublicKey:  "",
 		}
+		// The credentials file is optional locally, only the list columns and page size of the default namespace are read from it
+		if config, err := readTomlConfig(); err == nil {
+			localConfig.Columns = config.Namespace[namespace].Columns
+			localConfig.PageSize = config.Namespace[namespace].PageSize
+		}
+		return localConfig
 	}
-	homeDir, err := os.UserHomeDir()
+	config, err := readTomlConfig()
 	if err != nil {
-		log.Fatal("Error fetching home directory:", err)
-	}
-	var config TomlConfig
-	f := filepath.Join(homeDir, ".config", "kairos", "credentials")
-	if _, err := os.Stat(f); err != nil {
-	}
-	if _, err := toml.DecodeFile(f, &config); err != nil {
 		log.Fatal("Temporal credentials are missing. Please add credentials to .config/kairos/credentials")
 		os.Exit(0)
 	}
@@ -176,15 +197,14 @@ func (m model) KickoffWorkflow(workflowName string, payload string) (string, err
 	return we.GetRunID(), nil
 }
 
-func (m model) GetWorkflowHistory(workflowID string, runID string) ([]*history.HistoryEvent, error) {
+// Fetches every event of the run, the latest run is used when runID is empty
+func (m model) GetWorkflowHistory(ctx context.Context, workflowID string, runID string) ([]*history.HistoryEvent, error) {
 	temporalClient, _ := m.getTemporalClient()
-	defer temporalClient.Close()
-	historyList := temporalClient.GetWorkflowHistory(context.Background(), workflowID, runID, false, 0)
+	historyList := temporalClient.GetWorkflowHistory(ctx, workflowID, runID, false, temporalEnums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
 
 	events := []*history.HistoryEvent{}
 	for historyList.HasNext() {
 		historyEvent, err := historyList.Next()
-		println(historyEvent.String())
 		if err != nil {
 			return []*history.HistoryEvent{}, err
 		}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	temporalEnums "go.temporal.io/api/enums/v1"
+)
+
+// ========================================
+// Timeline View
+// ========================================
+
+const (
+	TIMELINE_LABEL_WIDTH = 32
+	// Characters used to draw the bars
+	TIMELINE_SCHEDULED_CHAR = '░'
+	TIMELINE_RUNNING_CHAR   = '█'
+	TIMELINE_PENDING_CHAR   = '▒'
+	TIMELINE_PENDING_END    = '▶'
+	TIMELINE_INSTANT_CHAR   = '◆'
+)
+
+var timelineBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+var timelineAxisStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
+var timelineCompletedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00"))
+var timelineFailedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+var timelinePendingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#5f87ff"))
+var timelineCanceledStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
+
+type timelineSpan struct {
+	scheduled time.Time
+	// Zero when the item never started (e.g. timers)
+	started time.Time
+	// Zero when the item is still pending
+	closed    time.Time
+	attempt   int32
+	closeType temporalEnums.EventType
+}
+
+func (s timelineSpan) isPending() bool {
+	return s.closed.IsZero()
+}
+
+var timelineStartedEventTypes = map[temporalEnums.EventType]bool{
+	temporalEnums.EVENT_TYPE_ACTIVITY_TASK_STARTED:            true,
+	temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED: true,
+	temporalEnums.EVENT_TYPE_NEXUS_OPERATION_STARTED:          true,
+}
+
+var timelineClosedEventTypes = map[temporalEnums.EventType]bool{
+	temporalEnums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:               true,
+	temporalEnums.EVENT_TYPE_ACTIVITY_TASK_FAILED:                  true,
+	temporalEnums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:               true,
+	temporalEnums.EVENT_TYPE_ACTIVITY_TASK_CANCELED:                true,
+	temporalEnums.EVENT_TYPE_TIMER_FIRED:                           true,
+	temporalEnums.EVENT_TYPE_TIMER_CANCELED:                        true,
+	temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:    true,
+	temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:       true,
+	temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TIMED_OUT:    true,
+	temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED:     true,
+	temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TERMINATED:   true,
+	temporalEnums.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_FAILED: true,
+	temporalEnums.EVENT_TYPE_NEXUS_OPERATION_COMPLETED:             true,
+	temporalEnums.EVENT_TYPE_NEXUS_OPERATION_FAILED:                true,
+	temporalEnums.EVENT_TYPE_NEXUS_OPERATION_TIMED_OUT:             true,
+	temporalEnums.EVENT_TYPE_NEXUS_OPERATION_CANCELED:              true,
+}
+
+// Items that only have a single event (signals, workflow started...) are instants and not pending
+var timelineDurationActionTypes = map[string]bool{
+	"Activity":        true,
+	"Timer":           true,
+	"Child Workflow":  true,
+	"Nexus Operation": true,
+}
+
+func getTimelineSpan(item *compactHistoryListItem) timelineSpan {
+	span := timelineSpan{scheduled: item.events[0].GetEventTime().AsTime()}
+	for _, historyEvent := range item.events[1:] {
+		eventType := historyEvent.GetEventType()
+		if timelineStartedEventTypes[eventType] {
+			span.started = historyEvent.GetEventTime().AsTime()
+			span.attempt = historyEvent.GetActivityTaskStartedEventAttributes().GetAttempt()
+		}
+		if timelineClosedEventTypes[eventType] {
+			span.closed = historyEvent.GetEventTime().AsTime()
+			span.closeType = eventType
+		}
+	}
+	if !timelineDurationActionTypes[item.actionType] {
+		span.closed = span.scheduled
+	}
+	return span
+}
+
+func getTimelineSpanStyle(span timelineSpan) lipgloss.Style {
+	switch span.closeType {
+	case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_FAILED,
+		temporalEnums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT,
+		temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED,
+		temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TIMED_OUT,
+		temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TERMINATED,
+		temporalEnums.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_FAILED:
+		return timelineFailedStyle
+	case temporalEnums.EVENT_TYPE_ACTIVITY_TASK_CANCELED,
+		temporalEnums.EVENT_TYPE_TIMER_CANCELED,
+		temporalEnums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED,
+		temporalEnums.EVENT_TYPE_NEXUS_OPERATION_CANCELED:
+		return timelineCanceledStyle
+	}
+	if span.isPending() {
+		return timelinePendingStyle
+	}
+	return timelineCompletedStyle
+}
+
+// Converts a time to a column in a bar of the given width
+func getTimelinePosition(t time.Time, rangeStart time.Time, rangeEnd time.Time, width int) int {
+	total := rangeEnd.Sub(rangeStart)
+	if total <= 0 {
+		return 0
+	}
+	position := int(float64(t.Sub(rangeStart)) / float64(total) * float64(width-1))
+	return max(0, min(width-1, position))
+}
+
+func renderTimelineBar(span timelineSpan, rangeStart time.Time, rangeEnd time.Time, width int) string {
+	bar := []rune(strings.Repeat(" ", width))
+	scheduledPosition := getTimelinePosition(span.scheduled, rangeStart, rangeEnd, width)
+	endTime := span.closed
+	if span.isPending() {
+		endTime = rangeEnd
+	}
+	endPosition := getTimelinePosition(endTime, rangeStart, rangeEnd, width)
+	// Timers and pending activities have no started event, the whole bar is running
+	startedPosition := scheduledPosition
+	if !span.started.IsZero() {
+		startedPosition = getTimelinePosition(span.started, rangeStart, rangeEnd, width)
+	}
+	fillChar := TIMELINE_RUNNING_CHAR
+	if span.isPending() {
+		fillChar = TIMELINE_PENDING_CHAR
+	}
+	for i := scheduledPosition; i <= endPosition; i++ {
+		if i < startedPosition {
+			bar[i] = TIMELINE_SCHEDULED_CHAR
+		} else {
+			bar[i] = fillChar
+		}
+	}
+	switch {
+	case span.isPending():
+		bar[endPosition] = TIMELINE_PENDING_END
+	case scheduledPosition == endPosition:
+		bar[scheduledPosition] = TIMELINE_INSTANT_CHAR
+	}
+	return getTimelineSpanStyle(span).Render(string(bar))
+}
+
+func renderTimelineLabel(item *compactHistoryListItem, span timelineSpan) string {
+	label := item.rowContent
+	if label == "" {
+		label = item.actionType
+	}
+	// Pending activities already show their attempt in the row content
+	if span.attempt > 1 && !strings.Contains(label, "🔄") {
+		label += " 🔄" + strconv.Itoa(int(span.attempt))
+	}
+	if span.isPending() {
+		label += " ⏳"
+	}
+	return truncateLine(item.icon+" "+label, TIMELINE_LABEL_WIDTH)
+}
+
+func (m model) renderTimeline(width int, height int) string {
+	currentHistoryStackItem := m.focusedWorkflowState.getCurrentHistoryStackItem()
+	compactHistorySlice := m.focusedWorkflowState.getCurrentCompactHistorySlice()
+	innerWidth := width - 2
+	barWidth := max(innerWidth-TIMELINE_LABEL_WIDTH-1, 10)
+	// An exported history can have no events, there is no start to draw the range from
+	if len(currentHistoryStackItem.history) == 0 {
+		return timelineBoxStyle.Width(innerWidth).Height(height).Render("No events in the history")
+	}
+
+	// The range goes from the start of the workflow to its close time, or now when it is running
+	rangeStart := currentHistoryStackItem.history[0].GetEventTime().AsTime()
+	rangeEnd := time.Now()
+	if closeTime := currentHistoryStackItem.workflowDescription.GetWorkflowExecutionInfo().GetCloseTime(); closeTime != nil {
+		rangeEnd = closeTime.AsTime()
+	}
+
+	spans := make([]timelineSpan, len(compactHistorySlice))
+	for i, compactHistoryItem := range compactHistorySlice {
+		spans[i] = getTimelineSpan(compactHistoryItem)
+		if !spans[i].isPending() && spans[i].closed.After(rangeEnd) {
+			rangeEnd = spans[i].closed
+		}
+	}
+
+	axisLabel := rangeStart.In(time.Local).Format(time.RFC3339)
+	durationLabel := rangeEnd.Sub(rangeStart).Round(time.Second).String()
+	axis := axisLabel + strings.Repeat(" ", max(1, barWidth-len(axisLabel)-len(durationLabel))) + durationLabel
+	lines := []string{timelineAxisStyle.Render(strings.Repeat(" ", TIMELINE_LABEL_WIDTH+1) + axis)}
+
+	// Keep the cursor visible by scrolling the window of rows
+	visibleRows := max(height-1, 1)
+	offset := max(0, m.focusedWorkflowState.cursor-visibleRows+1)
+	for i := offset; i < len(compactHistorySlice) && i < offset+visibleRows; i++ {
+		label := lipgloss.NewStyle().Width(TIMELINE_LABEL_WIDTH).MaxWidth(TIMELINE_LABEL_WIDTH).Render(renderTimelineLabel(compactHistorySlice[i], spans[i]))
+		if i == m.focusedWorkflowState.cursor {
+			label = SelectedRowStyle.Render(label)
+		}
+		lines = append(lines, label+" "+renderTimelineBar(spans[i], rangeStart, rangeEnd, barWidth))
+	}
+	return timelineBoxStyle.Width(innerWidth).Height(height).Render(strings.Join(lines, "\n"))
+}
@@ -2,9 +2,15 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
+// Escapes a value for a single quoted string in a visibility query
+func escapeQueryValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
+
 func getRelativeTimeDiff(t1, t2 time.Time) string {
 	t1 = t1.Local()
 	t2 = t2.Local()
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.temporal.io/api/common/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ========================================
+// Workflow Details
+// ========================================
+
+var workflowDetailsBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+var workflowDetailsLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080")).Width(22)
+
+type workflowDetailsField struct {
+	label string
+	value string
+}
+
+func formatDetailsTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return ""
+	}
+	return t.AsTime().In(time.Local).Format(time.RFC3339)
+}
+
+func formatDetailsDuration(d *durationpb.Duration) string {
+	if d == nil || d.AsDuration() == 0 {
+		return ""
+	}
+	return d.AsDuration().String()
+}
+
+func formatDetailsExecution(execution *common.WorkflowExecution) string {
+	if execution == nil {
+		return ""
+	}
+	return execution.GetWorkflowId() + " (" + execution.GetRunId() + ")"
+}
+
+func formatHistorySize(bytes int64) string {
+	switch {
+	case bytes >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
+	case bytes >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
+	}
+	return fmt.Sprintf("%d B", bytes)
+}
+
+// Payload maps are shown on a single line per field, e.g. "CustomerId=42, Region="eu""
+func formatDetailsPayloadMap(fields map[string]*common.Payload) string {
+	values := []string{}
+	for _, key := range getSortedPayloadMapKeys(fields) {
+		value := strings.Join(strings.Fields(convertDataToPrettyJSON(fields[key].GetData())), " ")
+		values = append(values, key+"="+value)
+	}
+	return strings.Join(values, ", ")
+}
+
+func formatDetailsRetryPolicy(retryPolicy *common.RetryPolicy) string {
+	if retryPolicy == nil {
+		return ""
+	}
+	maximumAttempts := "∞"
+	if retryPolicy.GetMaximumAttempts() > 0 {
+		maximumAttempts = fmt.Sprint(retryPolicy.GetMaximumAttempts())
+	}
+	parts := []string{
+		"initial " + formatDetailsDuration(retryPolicy.GetInitialInterval()),
+		fmt.Sprintf("backoff %.1f", retryPolicy.GetBackoffCoefficient()),
+		"max interval " + formatDetailsDuration(retryPolicy.GetMaximumInterval()),
+		"max attempts " + maximumAttempts,
+	}
+	if len(retryPolicy.GetNonRetryableErrorTypes()) > 0 {
+		parts = append(parts, "non retryable "+strings.Join(retryPolicy.GetNonRetryableErrorTypes(), ", "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (s compactHistoryStackItem) getWorkflowDetailsFields() []workflowDetailsField {
+	description := s.workflowDescription
+	info := description.GetWorkflowExecutionInfo()
+	executionConfig := description.GetExecutionConfig()
+	startedEventAttributes := getWorkflowStartedEventAttributes(s.history)
+	buildId := info.GetAssignedBuildId()
+	if buildId == "" {
+		buildId = info.GetMostRecentWorkerVersionStamp().GetBuildId()
+	}
+	deployment := info.GetVersioningInfo().GetDeployment()
+	deploymentName := ""
+	if deployment != nil {
+		deploymentName = deployment.GetSeriesName() + " " + deployment.GetBuildId()
+	}
+	fields := []workflowDetailsField{
+		{"Run Id", info.GetExecution().GetRunId()},
+		{"Type", info.GetType().GetName()},
+		{"Task Queue", info.GetTaskQueue()},
+		{"Start Time", formatDetailsTime(info.GetStartTime())},
+		{"Execution Time", formatDetailsTime(info.GetExecutionTime())},
+		{"Close Time", formatDetailsTime(info.GetCloseTime())},
+		{"History", fmt.Sprintf("%d events, %s", info.GetHistoryLength(), formatHistorySize(info.GetHistorySizeBytes()))},
+		{"Memo", formatDetailsPayloadMap(info.GetMemo().GetFields())},
+		{"Search Attributes", formatDetailsPayloadMap(info.GetSearchAttributes().GetIndexedFields())},
+		{"Retry Policy", formatDetailsRetryPolicy(startedEventAttributes.GetRetryPolicy())},
+		{"Cron Schedule", startedEventAttributes.GetCronSchedule()},
+		{"Execution Timeout", formatDetailsDuration(executionConfig.GetWorkflowExecutionTimeout())},
+		{"Run Timeout", formatDetailsDuration(executionConfig.GetWorkflowRunTimeout())},
+		{"Workflow Task Timeout", formatDetailsDuration(executionConfig.GetDefaultWorkflowTaskTimeout())},
+		{"Parent", formatDetailsExecution(info.GetParentExecution())},
+		{"Root", formatDetailsExecution(info.GetRootExecution())},
+		{"Build Id", buildId},
+		{"Deployment", deploymentName},
+	}
+	// Fields that are not set are hidden
+	setFields := []workflowDetailsField{}
+	for _, field := range fields {
+		if field.value != "" {
+			setFields = append(setFields, field)
+		}
+	}
+	return setFields
+}
+
+func (m model) renderWorkflowDetails(width int, maxHeight int) string {
+	innerWidth := width - 2
+	lines := []string{}
+	for _, field := range m.focusedWorkflowState.getCurrentHistoryStackItem().getWorkflowDetailsFields() {
+		line := workflowDetailsLabelStyle.Render(field.label) + field.value
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(innerWidth).Render(line))
+	}
+	if len(lines) > maxHeight {
+		lines = lines[:maxHeight]
+	}
+	return workflowDetailsBoxStyle.Width(innerWidth).Render(strings.Join(lines, "\n"))
+}
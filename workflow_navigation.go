@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	temporalEnums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+)
+
+// ========================================
+// Workflow Navigation
+// ========================================
+
+func getWorkflowStartedEventAttributes(historyList []*history.HistoryEvent) *history.WorkflowExecutionStartedEventAttributes {
+	if len(historyList) == 0 {
+		return nil
+	}
+	return historyList[0].GetWorkflowExecutionStartedEventAttributes()
+}
+
+// Run that continued as new into this run
+func getPreviousRunId(historyList []*history.HistoryEvent) string {
+	return getWorkflowStartedEventAttributes(historyList).GetContinuedExecutionRunId()
+}
+
+// Run started by continue-as-new, a retry or a cron schedule when this run closed
+func getNextRunId(historyList []*history.HistoryEvent) string {
+	if len(historyList) == 0 {
+		return ""
+	}
+	lastEvent := historyList[len(historyList)-1]
+	switch lastEvent.GetEventType() {
+	case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+		return lastEvent.GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId()
+	case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+		return lastEvent.GetWorkflowExecutionCompletedEventAttributes().GetNewExecutionRunId()
+	case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		return lastEvent.GetWorkflowExecutionFailedEventAttributes().GetNewExecutionRunId()
+	case temporalEnums.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		return lastEvent.GetWorkflowExecutionTimedOutEventAttributes().GetNewExecutionRunId()
+	}
+	return ""
+}
+
+func getFirstRunId(historyList []*history.HistoryEvent) string {
+	startedEventAttributes := getWorkflowStartedEventAttributes(historyList)
+	if startedEventAttributes.GetFirstExecutionRunId() != "" {
+		return startedEventAttributes.GetFirstExecutionRunId()
+	}
+	return startedEventAttributes.GetOriginalExecutionRunId()
+}
+
+func shortRunId(runId string) string {
+	if len(runId) > 8 {
+		return runId[:8]
+	}
+	return runId
+}
+
+var breadcrumbSeparator = " › "
+var breadcrumbStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
+var currentBreadcrumbStyle = lipgloss.NewStyle().Bold(true)
+
+// Renders the path of workflows that were focused, runs of the same workflow only show the run id
+func (m *focusedModeState) renderBreadcrumb(maxWidth int) string {
+	crumbs := []string{}
+	previousWorkflowId := ""
+	for _, stackItem := range m.compactedHistoryStack {
+		runId := stackItem.workflowDescription.GetWorkflowExecutionInfo().GetExecution().GetRunId()
+		crumb := stackItem.workflowId + " (" + shortRunId(runId) + ")"
+		if stackItem.workflowId == previousWorkflowId {
+			crumb = "run " + shortRunId(runId)
+		}
+		previousWorkflowId = stackItem.workflowId
+		crumbs = append(crumbs, crumb)
+	}
+	// Drop the oldest crumbs until the breadcrumb fits, the "…" crumb that replaces them counts towards the width
+	droppedCrumbs := false
+	getWidth := func() int {
+		width := lipgloss.Width(strings.Join(crumbs, breadcrumbSeparator))
+		if droppedCrumbs {
+			width += lipgloss.Width("…" + breadcrumbSeparator)
+		}
+		return width
+	}
+	for len(crumbs) > 1 && getWidth() > maxWidth {
+		crumbs = crumbs[1:]
+		droppedCrumbs = true
+	}
+	if overflow := getWidth() - maxWidth; overflow > 0 {
+		lastCrumb := crumbs[len(crumbs)-1]
+		crumbs[len(crumbs)-1] = ansi.Truncate(lastCrumb, max(lipgloss.Width(lastCrumb)-overflow, 1), "…")
+	}
+	renderedCrumbs := []string{}
+	if droppedCrumbs {
+		renderedCrumbs = append(renderedCrumbs, breadcrumbStyle.Render("…"))
+	}
+	for i, crumb := range crumbs {
+		if i == len(crumbs)-1 {
+			renderedCrumbs = append(renderedCrumbs, currentBreadcrumbStyle.Render(crumb))
+		} else {
+			renderedCrumbs = append(renderedCrumbs, breadcrumbStyle.Render(crumb))
+		}
+	}
+	return strings.Join(renderedCrumbs, breadcrumbStyle.Render(breadcrumbSeparator))
+}
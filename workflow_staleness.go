@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ========================================
+// Workflow Staleness
+// ========================================
+
+// Running workflows without a new event for this long are highlighted as idle
+const IDLE_WORKFLOW_THRESHOLD = time.Hour
+
+// Running workflows older than this are highlighted as long running
+const LONG_RUNNING_WORKFLOW_THRESHOLD = 24 * time.Hour
+
+// Reverse history calls made at the same time when fetching the last events of the list
+const LAST_EVENT_FETCH_CONCURRENCY = 8
+
+var staleWorkflowStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffa500"))
+
+// Re-renders the relative times of the list every second
+type relativeTimeTickMsg struct{}
+
+type updateVisibleWorkflowLastEventsMsg struct {
+	// Time of the last history event by run id
+	lastEventTimes map[string]time.Time
+}
+
+func relativeTimeTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(_ time.Time) tea.Msg {
+		return relativeTimeTickMsg{}
+	})
+}
+
+// The other views render their own times, the list is the only one that needs the tick
+func (m model) isListVisible() bool {
+	return len(m.focusedWorkflowState.compactedHistoryStack) == 0 && !m.diffViewState.active && m.runsViewState.workflowId == ""
+}
+
+// The tick stops while the list is hidden, it is started again when going back to the list
+func (m *model) resumeRelativeTimeTickCmd() tea.Cmd {
+	if m.relativeTimeTicking || !m.isListVisible() {
+		return nil
+	}
+	m.relativeTimeTicking = true
+	return relativeTimeTickCmd()
+}
+
+func (m model) handleRelativeTimeTickMsg() (tea.Model, tea.Cmd) {
+	if !m.isListVisible() {
+		m.relativeTimeTicking = false
+		return m, nil
+	}
+	// Nothing changes, the list is rendered again with the new relative times
+	return m, relativeTimeTickCmd()
+}
+
+func (m *model) getLastEventTime(workflowId string, runId string) (time.Time, error) {
+	temporalClient, _ := m.getTemporalClient()
+	namespaceInfo := m.getTemporalConfig()
+	response, err := temporalClient.WorkflowService().GetWorkflowExecutionHistoryReverse(context.Background(), &workflowservice.GetWorkflowExecutionHistoryReverseRequest{
+		Namespace:       namespaceInfo.TemporalNamespace,
+		Execution:       &common.WorkflowExecution{WorkflowId: workflowId, RunId: runId},
+		MaximumPageSize: 1,
+	})
+	if err != nil || len(response.GetHistory().GetEvents()) == 0 {
+		return time.Time{}, err
+	}
+	return response.GetHistory().GetEvents()[0].GetEventTime().AsTime(), nil
+}
+
+// Visibility does not record the last event, so the newest event of every running workflow is fetched.
+// The last event of a closed workflow is its close event.
+func (m *model) updateVisibleWorkflowLastEventsBackgroundCmd(delay time.Duration) tea.Cmd {
+	return tea.Tick(time.Second*delay, func(_ time.Time) tea.Msg {
+		lastEventTimes := make(map[string]time.Time)
+		var lastEventTimesMutex sync.Mutex
+		var waitGroup sync.WaitGroup
+		semaphore := make(chan struct{}, LAST_EVENT_FETCH_CONCURRENCY)
+		for _, execution := range m.workflows {
+			if execution.workflow.GetCloseTime() != nil {
+				continue
+			}
+			workflowId := execution.workflow.GetExecution().GetWorkflowId()
+			runId := execution.workflow.GetExecution().GetRunId()
+			semaphore <- struct{}{}
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				defer func() { <-semaphore }()
+				lastEventTime, err := m.getLastEventTime(workflowId, runId)
+				if err != nil {
+					return
+				}
+				lastEventTimesMutex.Lock()
+				defer lastEventTimesMutex.Unlock()
+				lastEventTimes[runId] = lastEventTime
+			}()
+		}
+		waitGroup.Wait()
+		return updateVisibleWorkflowLastEventsMsg{lastEventTimes: lastEventTimes}
+	})
+}
+
+func getWorkflowDuration(w *workflowTableListItem) time.Duration {
+	endTime := time.Now()
+	if w.workflow.GetCloseTime() != nil {
+		endTime = w.workflow.GetCloseTime().AsTime()
+	}
+	return endTime.Sub(w.workflow.GetStartTime().AsTime())
+}
+
+func renderWorkflowDuration(w *workflowTableListItem) string {
+	duration := getWorkflowDuration(w)
+	if w.workflow.GetCloseTime() == nil && duration > LONG_RUNNING_WORKFLOW_THRESHOLD {
+		return staleWorkflowStyle.Render(formatListDuration(duration))
+	}
+	return formatListDuration(duration)
+}
+
+func renderWorkflowLastEvent(w *workflowTableListItem) string {
+	if w.workflow.GetCloseTime() != nil {
+		return getRelativeTimeDiff(time.Now(), w.workflow.GetCloseTime().AsTime())
+	}
+	// Not fetched yet
+	if w.lastEventTime.IsZero() {
+		return "--"
+	}
+	lastEvent := getRelativeTimeDiff(time.Now(), w.lastEventTime)
+	if time.Since(w.lastEventTime) > IDLE_WORKFLOW_THRESHOLD {
+		return staleWorkflowStyle.Render(lastEvent)
+	}
+	return lastEvent
+}
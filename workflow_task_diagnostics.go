@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	temporalEnums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ========================================
+// Workflow Task Diagnostics
+// ========================================
+
+// Workflow task events are hidden in the compacted history, so a workflow that is stuck
+// on a failing workflow task (e.g. a non-determinism error) looks healthy without this
+type workflowTaskProblem struct {
+	// Failed or timed out workflow tasks since the last completed one
+	failedCount int
+	attempt     int32
+	cause       string
+	message     string
+	identity    string
+}
+
+// Returns nil when the workflow task is healthy
+func getWorkflowTaskProblem(historyList []*history.HistoryEvent, description *workflowservice.DescribeWorkflowExecutionResponse) *workflowTaskProblem {
+	problem := &workflowTaskProblem{}
+	startedEventIdentities := make(map[int64]string)
+	for _, historyEvent := range historyList {
+		switch historyEvent.GetEventType() {
+		case temporalEnums.EVENT_TYPE_WORKFLOW_TASK_STARTED:
+			startedEventIdentities[historyEvent.GetEventId()] = historyEvent.GetWorkflowTaskStartedEventAttributes().GetIdentity()
+		case temporalEnums.EVENT_TYPE_WORKFLOW_TASK_COMPLETED:
+			problem = &workflowTaskProblem{}
+		case temporalEnums.EVENT_TYPE_WORKFLOW_TASK_FAILED:
+			attributes := historyEvent.GetWorkflowTaskFailedEventAttributes()
+			problem.failedCount++
+			problem.cause = attributes.GetCause().String()
+			problem.message = attributes.GetFailure().GetMessage()
+			problem.identity = attributes.GetIdentity()
+		case temporalEnums.EVENT_TYPE_WORKFLOW_TASK_TIMED_OUT:
+			attributes := historyEvent.GetWorkflowTaskTimedOutEventAttributes()
+			problem.failedCount++
+			problem.cause = "TimedOut (" + attributes.GetTimeoutType().String() + ")"
+			problem.message = ""
+			problem.identity = startedEventIdentities[attributes.GetStartedEventId()]
+		}
+	}
+	// The server only records the first failure of a retrying workflow task, the attempt lives on the pending task
+	problem.attempt = description.GetPendingWorkflowTask().GetAttempt()
+	// A single failure or timeout (e.g. a sticky queue timeout) is retried right away, only repeated failures are a problem
+	if problem.failedCount <= 1 && problem.attempt <= 1 {
+		return nil
+	}
+	if problem.attempt == 0 {
+		problem.attempt = int32(problem.failedCount)
+	}
+	// The failure event is not in the loaded history, so the pending task is the only thing that explains the banner
+	if problem.cause == "" {
+		problem.cause = "Retrying (pending task " + description.GetPendingWorkflowTask().GetState().String() + ")"
+	}
+	return problem
+}
+
+var workflowTaskBannerStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("#ff0000")).
+	Foreground(lipgloss.Color("#ff0000")).
+	Bold(true)
+
+func (p *workflowTaskProblem) renderBanner(width int) string {
+	lines := []string{"⚠️  Workflow task failing: " + p.cause + " (attempt " + strconv.Itoa(int(p.attempt)) + ")"}
+	if p.identity != "" {
+		lines = append(lines, "Worker: "+p.identity)
+	}
+	if p.message != "" {
+		// Keep the banner short, the full failure is in the raw event view
+		lines = append(lines, truncateLine(p.message, width-4))
+	}
+	return workflowTaskBannerStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
+
+func truncateLine(text string, maxWidth int) string {
+	text = strings.SplitN(text, "\n", 2)[0]
+	if maxWidth <= 3 || lipgloss.Width(text) <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	if len(runes) > maxWidth-3 {
+		runes = runes[:maxWidth-3]
+	}
+	return string(runes) + "..."
+}
+
+func formatWorkflowTaskAttempts(attempts int32) string {
+	return fmt.Sprintf("⚠️ WFT x%d", attempts)
+}